@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/confetti-framework/contract/inter"
 	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation/config"
 	"github.com/confetti-framework/foundation/loggers"
 )
 
@@ -36,6 +37,26 @@ func (a *Application) Singleton(abstract interface{}, concrete interface{}) {
 	(*a.container).Singleton(abstract, concrete)
 }
 
+// Register a binding that is shared within one request.
+func (a *Application) Scoped(abstract interface{}, concrete interface{}) {
+	a.foundationContainer().Scoped(abstract, concrete)
+}
+
+// Register a binding that is created every time it is resolved.
+func (a *Application) Transient(abstract interface{}, concrete interface{}) {
+	a.foundationContainer().Transient(abstract, concrete)
+}
+
+// Resolve all abstracts of the given tag.
+func (a *Application) Tagged(tag string) []interface{} {
+	return a.foundationContainer().Tagged(tag)
+}
+
+// Resolve all abstracts of the given tag or give an error.
+func (a *Application) TaggedE(tag string) ([]interface{}, error) {
+	return a.foundationContainer().TaggedE(tag)
+}
+
 // Make the given type from the container.
 func (a *Application) Make(abstract interface{}) interface{} {
 	return (*a.container).Make(abstract)
@@ -72,6 +93,22 @@ func (a *Application) IsEnvironment(environments ...string) bool {
 	return false
 }
 
+// Bind the configuration of the key (e.g. "Logging") into a struct. Use
+// struct tags for defaults and validation, see config.Decode.
+func (a *Application) ConfigInto(key string, target interface{}) error {
+	source, err := a.MakeE("config." + key)
+	if err != nil {
+		return errors.Wrap(err, "bind config '%s'", key)
+	}
+
+	err = config.Decode(source, target)
+	if err != nil {
+		return errors.Wrap(err, "bind config '%s'", key)
+	}
+
+	return nil
+}
+
 func (a *Application) Log(channels ...string) inter.LoggerFacade {
 	// If no channels are specified, take the default
 	if len(channels) == 0 {
@@ -96,3 +133,12 @@ func (a *Application) getLoggerByChannel(channel string) inter.Logger {
 
 	return rawLogger.(inter.Logger)
 }
+
+func (a *Application) foundationContainer() *Container {
+	container, ok := (*a.container).(*Container)
+	if !ok {
+		panic(errors.New("container does not support lifetimes, contextual bindings and tags. Use foundation.Container"))
+	}
+
+	return container
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"github.com/confetti-framework/errors"
+	"io/ioutil"
+	"os"
+)
+
+type cache struct {
+	Environment string                 `json:"environment"`
+	Values      map[string]interface{} `json:"values"`
+}
+
+// Cache the values of the files and the environment variables for the given
+// environment. The loader uses the cache instead of merging the sources again.
+func (l Loader) WriteCache(environment string) error {
+	if l.Cache == "" {
+		return CacheNotConfiguredError
+	}
+
+	overrides, err := l.Overrides(environment)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(cache{Environment: environment, Values: overrides}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "can not encode configuration cache")
+	}
+
+	// The cache contains the secrets of the environment variables
+	err = ioutil.WriteFile(l.Cache, content, 0600)
+	if err == nil {
+		// WriteFile doesn't change the permissions of an existing file
+		err = os.Chmod(l.Cache, 0600)
+	}
+	if err != nil {
+		return errors.Wrap(err, "can not write configuration cache '%s'", l.Cache)
+	}
+
+	return nil
+}
+
+// Remove the cache file. Nothing happens if the file does not exist.
+func (l Loader) ClearCache() error {
+	if l.Cache == "" {
+		return CacheNotConfiguredError
+	}
+
+	err := os.Remove(l.Cache)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "can not remove configuration cache '%s'", l.Cache)
+	}
+
+	return nil
+}
+
+// Get the cached values. The second value is false if there is no cache.
+func (l Loader) cached(environment string) (map[string]interface{}, bool, error) {
+	if l.Cache == "" {
+		return nil, false, nil
+	}
+
+	content, err := ioutil.ReadFile(l.Cache)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.Wrap(err, "can not read configuration cache '%s'", l.Cache)
+	}
+
+	var result cache
+	err = json.Unmarshal(content, &result)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "can not parse configuration cache '%s'", l.Cache)
+	}
+	if result.Environment != environment {
+		return nil, false, CacheEnvironmentMismatchError.Wrap(
+			"cache '%s' is for environment '%s', not for '%s'",
+			l.Cache,
+			result.Environment,
+			environment,
+		)
+	}
+
+	return result.Values, true, nil
+}
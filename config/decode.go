@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/cast"
+	"reflect"
+	"strings"
+)
+
+var floatType = reflect.TypeOf(float64(0))
+
+// Decode the configuration into a struct. The source may be a struct or a
+// map; keys that don't exist in the target are ignored. Defaults are only
+// used for keys that are missing in the source. The target is configured
+// with struct tags:
+//
+//	type LoggingConfig struct {
+//		Default string        `config:"default_channel" required:"true"`
+//		Retries int           `default:"3" min:"1" max:"10"`
+//		Timeout time.Duration `default:"5s" max:"1m"`
+//	}
+//
+// The error contains every field that is missing or has an invalid value.
+func Decode(source interface{}, target interface{}) error {
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() || pointer.Elem().Kind() != reflect.Struct {
+		return InvalidTargetError.Wrap("expected a pointer to a struct, %T given", target)
+	}
+
+	m := merger{ignoreUnknown: true}
+	current := m.defaults(pointer.Elem().Interface(), nil)
+	if source != nil {
+		current = m.merge(current, nil, normalize(source))
+	}
+	m.validate(current, nil)
+
+	if len(m.problems) > 0 {
+		return InvalidConfigurationError.Wrap(strings.Join(m.problems, "; "))
+	}
+
+	pointer.Elem().Set(reflect.ValueOf(current))
+
+	return nil
+}
+
+// Fill the empty fields with the value of the default tag.
+func (m *merger) defaults(current interface{}, done []string) interface{} {
+	source := reflect.ValueOf(current)
+	result := reflect.New(source.Type()).Elem()
+	result.Set(source)
+
+	for i := 0; i < source.NumField(); i++ {
+		field := source.Type().Field(i)
+		key, ok := fieldKey(field)
+		if !ok {
+			continue
+		}
+		value := result.Field(i)
+		path := append(done, key)
+
+		if value.Kind() == reflect.Struct {
+			value.Set(reflect.ValueOf(m.defaults(value.Interface(), path)))
+			continue
+		}
+
+		raw, present := field.Tag.Lookup("default")
+		if !present || !value.IsZero() {
+			continue
+		}
+		converted, err := convert(raw, field.Type)
+		if err != nil {
+			m.problem(path, "invalid default: %s", err)
+			continue
+		}
+		value.Set(reflect.ValueOf(converted))
+	}
+
+	return result.Interface()
+}
+
+// Validate the fields with the required, min and max tags.
+func (m *merger) validate(current interface{}, done []string) {
+	source := reflect.ValueOf(current)
+	for i := 0; i < source.NumField(); i++ {
+		field := source.Type().Field(i)
+		key, ok := fieldKey(field)
+		if !ok {
+			continue
+		}
+		value := source.Field(i)
+		path := append(done, key)
+
+		if field.Tag.Get("required") == "true" && value.IsZero() {
+			m.problems = append(m.problems, fmt.Sprintf("key '%s' is required", strings.Join(path, ".")))
+			continue
+		}
+
+		if value.Kind() == reflect.Struct {
+			m.validate(value.Interface(), path)
+			continue
+		}
+
+		if raw, present := field.Tag.Lookup("min"); present {
+			m.compare(value, raw, path, "at least", func(value, bound float64) bool { return value >= bound })
+		}
+		if raw, present := field.Tag.Lookup("max"); present {
+			m.compare(value, raw, path, "at most", func(value, bound float64) bool { return value <= bound })
+		}
+	}
+}
+
+// Compare a number with the bound. Strings, slices and maps are compared by length.
+func (m *merger) compare(value reflect.Value, raw string, path []string, description string, valid func(value, bound float64) bool) {
+	var actual, bound float64
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		length, err := cast.ToIntE(raw)
+		if err != nil {
+			m.problem(path, "invalid bound '%s'", raw)
+			return
+		}
+		if !valid(float64(value.Len()), float64(length)) {
+			m.problem(path, "length must be %s %d", description, length)
+		}
+		return
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		converted, err := convert(raw, value.Type())
+		if err != nil {
+			m.problem(path, "invalid bound '%s'", raw)
+			return
+		}
+		actual = value.Convert(floatType).Float()
+		bound = reflect.ValueOf(converted).Convert(floatType).Float()
+	default:
+		m.problem(path, "can not compare %s", value.Type())
+		return
+	}
+
+	if !valid(actual, bound) {
+		m.problem(path, "must be %s %s", description, raw)
+	}
+}
+
+// Convert structs and maps to maps, so they can be merged into any struct.
+func normalize(value interface{}) interface{} {
+	source := reflect.ValueOf(value)
+	switch source.Kind() {
+	case reflect.Ptr:
+		if source.IsNil() {
+			return nil
+		}
+		return normalize(source.Elem().Interface())
+	case reflect.Struct:
+		// A struct is fully specified, so an explicit false or 0 is kept
+		// instead of being replaced by the default. Only nil fields are
+		// skipped, they have no value at all.
+		result := map[string]interface{}{}
+		for i := 0; i < source.NumField(); i++ {
+			key, ok := fieldKey(source.Type().Field(i))
+			if ok && !isNil(source.Field(i)) {
+				result[key] = normalize(source.Field(i).Interface())
+			}
+		}
+		return result
+	case reflect.Map:
+		if source.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		result := map[string]interface{}{}
+		for _, key := range source.MapKeys() {
+			result[key.String()] = normalize(source.MapIndex(key).Interface())
+		}
+		return result
+	}
+
+	return value
+}
+
+func isNil(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return value.IsNil()
+	}
+
+	return false
+}
+
+// Get the key of an exported field. The key can be changed with the config tag.
+func fieldKey(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+
+	key := field.Tag.Get("config")
+	if key == "-" {
+		return "", false
+	}
+	if key == "" {
+		key = field.Name
+	}
+
+	return key, true
+}
@@ -0,0 +1,13 @@
+package config
+
+import "github.com/confetti-framework/errors"
+
+var InvalidConfigurationError = errors.New("invalid configuration")
+
+var UnsupportedFileError = errors.New("unsupported configuration file")
+
+var InvalidTargetError = errors.New("invalid target to decode the configuration")
+
+var CacheNotConfiguredError = errors.New("no configuration cache file configured")
+
+var CacheEnvironmentMismatchError = errors.New("the configuration cache is for another environment")
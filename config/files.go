@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"github.com/BurntSushi/toml"
+	"github.com/confetti-framework/errors"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// Parse a JSON, YAML or TOML file to a map.
+func parseFile(file string) (map[string]interface{}, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "can not read configuration file '%s'", file)
+	}
+
+	values := map[string]interface{}{}
+	switch filepath.Ext(file) {
+	case ".json":
+		err = json.Unmarshal(content, &values)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+	case ".toml":
+		err = toml.Unmarshal(content, &values)
+	default:
+		return nil, UnsupportedFileError.Wrap("file '%s'", file)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "can not parse configuration file '%s'", file)
+	}
+
+	return values, nil
+}
+
+// Parse a .env file to lines with KEY=value. Empty lines and comments are
+// skipped, the export keyword is removed and quoted values are unquoted.
+func parseDotEnv(file string) ([]string, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "can not read configuration file '%s'", file)
+	}
+
+	var result []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("can not parse line %d of configuration file '%s'", number, file)
+		}
+
+		key := strings.TrimSpace(parts[0])
+		result = append(result, key+"="+dotEnvValue(strings.TrimSpace(parts[1])))
+	}
+
+	return result, scanner.Err()
+}
+
+func dotEnvValue(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		value = value[1 : len(value)-1]
+		return strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value)
+	}
+
+	// Remove comments after the value
+	if index := strings.Index(value, " #"); index >= 0 {
+		value = strings.TrimSpace(value[:index])
+	}
+
+	return value
+}
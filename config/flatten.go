@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// SensitiveKeys matches the keys of values that should not be shown.
+var SensitiveKeys = regexp.MustCompile(`(?i)(password|secret|token|key|dsn|credential)`)
+
+// The value that is shown instead of a sensitive value
+const Masked = "********"
+
+// Flatten the index to dotted keys (e.g. "App.Name"). Structs and maps are
+// flattened, all other values (including slices) are kept as they are.
+func Flatten(index map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	flatten(result, "", index)
+
+	return result
+}
+
+// Mask the values of keys that match SensitiveKeys. Every segment of the
+// key is matched, so both "Database.Password" and "Secrets.Stripe" are masked.
+func Mask(flat map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(flat))
+	for key, value := range flat {
+		if isSensitive(key) {
+			value = Masked
+		}
+		result[key] = value
+	}
+
+	return result
+}
+
+func isSensitive(key string) bool {
+	for _, segment := range strings.Split(key, ".") {
+		if SensitiveKeys.MatchString(segment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func flatten(result map[string]interface{}, prefix string, value interface{}) {
+	source := reflect.ValueOf(value)
+	switch source.Kind() {
+	case reflect.Struct:
+		fields := 0
+		for i := 0; i < source.NumField(); i++ {
+			if key, ok := fieldKey(source.Type().Field(i)); ok {
+				fields++
+				flatten(result, join(prefix, key), source.Field(i).Interface())
+			}
+		}
+		if fields > 0 {
+			return
+		}
+	case reflect.Map:
+		if source.Len() == 0 {
+			break
+		}
+		for _, key := range source.MapKeys() {
+			flatten(result, join(prefix, fmt.Sprint(key.Interface())), source.MapIndex(key).Interface())
+		}
+		return
+	}
+
+	result[prefix] = value
+}
+
+func join(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"fmt"
+	"github.com/confetti-framework/errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Loader merges the configuration from multiple sources into one index. Each
+// source overrides the sources before it:
+//
+//  1. the defaults
+//  2. the configuration files (e.g. config.yaml)
+//  3. the configuration files of the environment (e.g. config.production.yaml)
+//  4. the .env file and the .env file of the environment (e.g. .env.production)
+//  5. the environment variables
+//
+// Keys are matched case-insensitive. Nested keys in environment variables are
+// separated by a double underscore: APP_DATABASE__HOST sets Database.Host.
+type Loader struct {
+	// The index with the default values. The values are usually structs.
+	Defaults map[string]interface{}
+
+	// The directory with the configuration files and the .env files.
+	Path string
+
+	// The names of the configuration files without extension, "config" by
+	// default. The files may have the extension .json, .yaml, .yml or .toml.
+	Files []string
+
+	// Only environment variables with this prefix are used, "APP_" by default.
+	Prefix string
+
+	// The keys that must have a value (e.g. "App.Key").
+	Required []string
+
+	// Returns the environment variables, os.Environ by default.
+	Environ func() []string
+
+	// The file with the cached configuration (see Cache). When the file
+	// exists, the files and environment variables are not loaded. A cache
+	// of another environment is ignored.
+	Cache string
+
+	// Receives the problems that don't stop the configuration from loading
+	// (e.g. an ignored cache). The problems are written to os.Stderr by default.
+	Warn func(err error)
+}
+
+var extensions = []string{".json", ".yaml", ".yml", ".toml"}
+
+// Load the configuration for the given environment. The error
+// contains every key that is missing or has an invalid value.
+func (l Loader) Load(environment string) (map[string]interface{}, error) {
+	overrides, cached, err := l.cached(environment)
+	if errors.Is(err, CacheEnvironmentMismatchError) {
+		// Don't fail on a stale cache, so config:cache can replace it
+		l.warn(err)
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !cached {
+		overrides, err = l.Overrides(environment)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m := merger{}
+	index := m.merge(copyIndex(l.Defaults), nil, overrides)
+
+	for _, key := range l.Required {
+		if !filled(index, strings.Split(key, ".")) {
+			m.problems = append(m.problems, "key '"+key+"' is required")
+		}
+	}
+
+	if len(m.problems) > 0 {
+		return nil, InvalidConfigurationError.Wrap(strings.Join(m.problems, "; "))
+	}
+
+	return index.(map[string]interface{}), nil
+}
+
+// Overrides merges the values of the files and the environment variables
+// without the defaults. The cache is ignored.
+func (l Loader) Overrides(environment string) (map[string]interface{}, error) {
+	m := merger{raw: true}
+	var overrides interface{} = map[string]interface{}{}
+
+	files, err := l.files(environment)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		values, err := parseFile(file)
+		if err != nil {
+			return nil, err
+		}
+		overrides = m.merge(overrides, nil, values)
+	}
+
+	variables, err := l.variables(environment)
+	if err != nil {
+		return nil, err
+	}
+	for _, variable := range variables {
+		overrides = m.merge(overrides, variable.path, variable.value)
+	}
+
+	return overrides.(map[string]interface{}), nil
+}
+
+func (l Loader) warn(err error) {
+	if l.Warn != nil {
+		l.Warn(err)
+		return
+	}
+
+	_, _ = fmt.Fprintln(os.Stderr, "Warning: "+err.Error())
+}
+
+// Get the existing configuration files in the order they should be loaded.
+func (l Loader) files(environment string) ([]string, error) {
+	names := l.Files
+	if len(names) == 0 {
+		names = []string{"config"}
+	}
+
+	var result []string
+	for _, suffix := range suffixes(environment) {
+		for _, name := range names {
+			for _, extension := range extensions {
+				file := filepath.Join(l.Path, name+suffix+extension)
+				present, err := exists(file)
+				if err != nil {
+					return nil, err
+				}
+				if present {
+					result = append(result, file)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+type variable struct {
+	path  []string
+	value interface{}
+}
+
+// Get the variables from the .env files and the environment. The environment
+// variables are last, so they override the values from the .env files.
+func (l Loader) variables(environment string) ([]variable, error) {
+	var lines []string
+	for _, suffix := range suffixes(environment) {
+		file := filepath.Join(l.Path, ".env"+suffix)
+		present, err := exists(file)
+		if err != nil {
+			return nil, err
+		}
+		if !present {
+			continue
+		}
+		fileLines, err := parseDotEnv(file)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, fileLines...)
+	}
+
+	environ := l.Environ
+	if environ == nil {
+		environ = os.Environ
+	}
+	lines = append(lines, environ()...)
+
+	prefix := l.Prefix
+	if prefix == "" {
+		prefix = "APP_"
+	}
+
+	var result []variable
+	for _, line := range lines {
+		key, value := splitVariable(line)
+		if !strings.HasPrefix(key, prefix) || key == prefix {
+			continue
+		}
+		result = append(result, variable{
+			path:  strings.Split(strings.TrimPrefix(key, prefix), "__"),
+			value: value,
+		})
+	}
+
+	return result, nil
+}
+
+func suffixes(environment string) []string {
+	if environment == "" {
+		return []string{""}
+	}
+
+	return []string{"", "." + environment}
+}
+
+func splitVariable(line string) (string, string) {
+	parts := strings.SplitN(line, "=", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+func exists(file string) (bool, error) {
+	info, err := os.Stat(file)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "can not read configuration file '%s'", file)
+	}
+
+	return !info.IsDir(), nil
+}
+
+// Copy the index so the defaults are never changed
+func copyIndex(index map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(index))
+	for key, value := range index {
+		result[key] = value
+	}
+
+	return result
+}
@@ -0,0 +1,258 @@
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/cast"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// merger collects the problems so all invalid keys can be reported at once.
+type merger struct {
+	problems []string
+	// Ignore keys that don't exist in a struct
+	ignoreUnknown bool
+	// Replace values without converting them to the type of the current value
+	raw bool
+}
+
+// Merge the value into the current value on the given path. The current
+// value is never changed; a changed copy is returned instead.
+func (m *merger) merge(current interface{}, path []string, value interface{}) interface{} {
+	return m.set(current, nil, path, value)
+}
+
+func (m *merger) set(current interface{}, done []string, path []string, value interface{}) interface{} {
+	if len(path) == 0 {
+		return m.replace(current, done, value)
+	}
+
+	if current == nil {
+		current = map[string]interface{}{}
+	}
+
+	source := reflect.ValueOf(current)
+	switch source.Kind() {
+	case reflect.Map:
+		if source.Type().Key().Kind() != reflect.String {
+			m.problem(done, "can not set key '%s' in %s", path[0], source.Type())
+			return current
+		}
+		result := reflect.MakeMapWithSize(source.Type(), source.Len()+1)
+		key := reflect.ValueOf(path[0]).Convert(source.Type().Key())
+		var child interface{}
+		for _, existing := range source.MapKeys() {
+			result.SetMapIndex(existing, source.MapIndex(existing))
+			if strings.EqualFold(existing.String(), path[0]) {
+				key = existing
+				child = source.MapIndex(existing).Interface()
+			}
+		}
+		newChild := m.set(child, append(done, key.String()), path[1:], value)
+		if !m.assign(result, key, newChild, append(done, key.String())) {
+			return current
+		}
+		return result.Interface()
+	case reflect.Struct:
+		result := reflect.New(source.Type()).Elem()
+		result.Set(source)
+		field, ok := structField(source.Type(), path[0])
+		if !ok {
+			if !m.ignoreUnknown {
+				m.problem(append(done, path[0]), "key does not exist")
+			}
+			return current
+		}
+		key, _ := fieldKey(field)
+		newChild := m.set(result.FieldByIndex(field.Index).Interface(), append(done, key), path[1:], value)
+		if !m.assignField(result.FieldByIndex(field.Index), newChild, append(done, key)) {
+			return current
+		}
+		return result.Interface()
+	case reflect.Ptr:
+		if source.IsNil() {
+			m.problem(done, "can not set key '%s' in nil pointer", path[0])
+			return current
+		}
+		result := reflect.New(source.Type().Elem())
+		newElem := m.set(source.Elem().Interface(), done, path, value)
+		if !m.assignField(result.Elem(), newElem, done) {
+			return current
+		}
+		return result.Interface()
+	}
+
+	m.problem(done, "can not set key '%s' in %s", path[0], source.Type())
+	return current
+}
+
+// Replace the current value. Maps are merged into the current value, all other values
+// are converted to the type of the current value.
+func (m *merger) replace(current interface{}, done []string, value interface{}) interface{} {
+	if values, ok := value.(map[string]interface{}); ok && current != nil {
+		kind := reflect.TypeOf(current).Kind()
+		if kind == reflect.Map || kind == reflect.Struct || kind == reflect.Ptr {
+			for key, child := range values {
+				current = m.set(current, done, []string{key}, child)
+			}
+			return current
+		}
+	}
+
+	if current == nil || m.raw {
+		return value
+	}
+	if value == nil {
+		return reflect.Zero(reflect.TypeOf(current)).Interface()
+	}
+
+	result, err := convert(value, reflect.TypeOf(current))
+	if err != nil {
+		m.problem(done, "%s", err)
+		return current
+	}
+
+	return result
+}
+
+func (m *merger) assign(target reflect.Value, key reflect.Value, value interface{}, done []string) bool {
+	converted, ok := m.assignable(value, target.Type().Elem(), done)
+	if ok {
+		target.SetMapIndex(key, converted)
+	}
+	return ok
+}
+
+func (m *merger) assignField(target reflect.Value, value interface{}, done []string) bool {
+	converted, ok := m.assignable(value, target.Type(), done)
+	if ok {
+		target.Set(converted)
+	}
+	return ok
+}
+
+func (m *merger) assignable(value interface{}, target reflect.Type, done []string) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Zero(target), true
+	}
+
+	converted, err := convert(value, target)
+	if err != nil {
+		m.problem(done, "%s", err)
+		return reflect.Value{}, false
+	}
+
+	return reflect.ValueOf(converted), true
+}
+
+func (m *merger) problem(done []string, format string, arguments ...interface{}) {
+	m.problems = append(m.problems, fmt.Sprintf("key '%s': ", strings.Join(done, "."))+fmt.Sprintf(format, arguments...))
+}
+
+// Convert a value from a file or an environment variable to the given type.
+func convert(value interface{}, target reflect.Type) (interface{}, error) {
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(target) {
+		return value, nil
+	}
+
+	var result interface{}
+	var err error
+	switch {
+	case target == durationType:
+		result, err = cast.ToDurationE(value)
+	case target.Kind() == reflect.String:
+		result, err = cast.ToStringE(value)
+	case target.Kind() == reflect.Bool:
+		result, err = cast.ToBoolE(value)
+	case target.Kind() >= reflect.Int && target.Kind() <= reflect.Int64:
+		result, err = cast.ToInt64E(value)
+	case target.Kind() >= reflect.Uint && target.Kind() <= reflect.Uint64:
+		result, err = cast.ToUint64E(value)
+	case target.Kind() == reflect.Float32 || target.Kind() == reflect.Float64:
+		result, err = cast.ToFloat64E(value)
+	case target.Kind() == reflect.Slice:
+		return convertSlice(value, target)
+	default:
+		err = fmt.Errorf("unsupported type %s", target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can not convert %#v to %s", value, target)
+	}
+
+	return reflect.ValueOf(result).Convert(target).Interface(), nil
+}
+
+// Convert a slice or a comma separated string to a slice of the given type.
+func convertSlice(value interface{}, target reflect.Type) (interface{}, error) {
+	var items []interface{}
+	source := reflect.ValueOf(value)
+	switch {
+	case source.Kind() == reflect.String:
+		for _, item := range strings.Split(source.String(), ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+	case source.Kind() == reflect.Slice:
+		for i := 0; i < source.Len(); i++ {
+			items = append(items, source.Index(i).Interface())
+		}
+	default:
+		return nil, fmt.Errorf("can not convert %#v to %s", value, target)
+	}
+
+	result := reflect.MakeSlice(target, 0, len(items))
+	for _, item := range items {
+		converted, err := convert(item, target.Elem())
+		if err != nil {
+			return nil, err
+		}
+		result = reflect.Append(result, reflect.ValueOf(converted))
+	}
+
+	return result.Interface(), nil
+}
+
+// Find an exported field by key, case-insensitive.
+func structField(source reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < source.NumField(); i++ {
+		field := source.Field(i)
+		if key, ok := fieldKey(field); ok && strings.EqualFold(key, name) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// Determine if the key has a value that is not empty.
+func filled(current interface{}, path []string) bool {
+	if len(path) == 0 {
+		return current != nil && !reflect.ValueOf(current).IsZero()
+	}
+	if current == nil {
+		return false
+	}
+
+	source := reflect.ValueOf(current)
+	switch source.Kind() {
+	case reflect.Map:
+		for _, key := range source.MapKeys() {
+			if key.Kind() == reflect.String && strings.EqualFold(key.String(), path[0]) {
+				return filled(source.MapIndex(key).Interface(), path[1:])
+			}
+		}
+	case reflect.Struct:
+		if field, ok := structField(source.Type(), path[0]); ok {
+			return filled(source.FieldByIndex(field.Index).Interface(), path[1:])
+		}
+	case reflect.Ptr:
+		if !source.IsNil() {
+			return filled(source.Elem().Interface(), path)
+		}
+	}
+
+	return false
+}
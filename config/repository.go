@@ -0,0 +1,154 @@
+package config
+
+import (
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/support"
+	"github.com/spf13/cast"
+	"time"
+)
+
+// Repository gives typed access to the configuration.
+type Repository struct {
+	index map[string]interface{}
+}
+
+func NewRepository(index map[string]interface{}) Repository {
+	return Repository{index: index}
+}
+
+// All returns the complete index.
+func (r Repository) All() map[string]interface{} {
+	return r.index
+}
+
+// Get a value by a dotted key (e.g. "App.Debug").
+func (r Repository) Get(key string) interface{} {
+	result, err := r.GetE(key)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (r Repository) GetE(key string) (interface{}, error) {
+	index, err := support.NewMapE(r.index)
+	if err != nil {
+		return nil, errors.Wrap(err, "get config '%s'", key)
+	}
+	value, err := index.GetE(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "get config '%s'", key)
+	}
+
+	return value.Raw(), nil
+}
+
+func (r Repository) String(key string) string {
+	result, err := r.StringE(key)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (r Repository) StringE(key string) (string, error) {
+	value, err := r.GetE(key)
+	if err != nil {
+		return "", err
+	}
+	result, err := cast.ToStringE(value)
+	return result, wrapCastError(err, key)
+}
+
+func (r Repository) Int(key string) int {
+	result, err := r.IntE(key)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (r Repository) IntE(key string) (int, error) {
+	value, err := r.GetE(key)
+	if err != nil {
+		return 0, err
+	}
+	result, err := cast.ToIntE(value)
+	return result, wrapCastError(err, key)
+}
+
+func (r Repository) Float(key string) float64 {
+	result, err := r.FloatE(key)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (r Repository) FloatE(key string) (float64, error) {
+	value, err := r.GetE(key)
+	if err != nil {
+		return 0, err
+	}
+	result, err := cast.ToFloat64E(value)
+	return result, wrapCastError(err, key)
+}
+
+func (r Repository) Bool(key string) bool {
+	result, err := r.BoolE(key)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (r Repository) BoolE(key string) (bool, error) {
+	value, err := r.GetE(key)
+	if err != nil {
+		return false, err
+	}
+	result, err := cast.ToBoolE(value)
+	return result, wrapCastError(err, key)
+}
+
+func (r Repository) Duration(key string) time.Duration {
+	result, err := r.DurationE(key)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (r Repository) DurationE(key string) (time.Duration, error) {
+	value, err := r.GetE(key)
+	if err != nil {
+		return 0, err
+	}
+	result, err := cast.ToDurationE(value)
+	return result, wrapCastError(err, key)
+}
+
+func (r Repository) Strings(key string) []string {
+	result, err := r.StringsE(key)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (r Repository) StringsE(key string) ([]string, error) {
+	value, err := r.GetE(key)
+	if err != nil {
+		return nil, err
+	}
+	result, err := cast.ToStringSliceE(value)
+	return result, wrapCastError(err, key)
+}
+
+func wrapCastError(err error, key string) error {
+	if err == nil {
+		return nil
+	}
+
+	return errors.Wrap(err, "get config '%s'", key)
+}
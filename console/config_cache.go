@@ -0,0 +1,41 @@
+package console
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/config"
+)
+
+type ConfigCache struct {
+	Clear bool `flag:"clear" description:"Remove the configuration cache"`
+}
+
+func (s ConfigCache) Name() string {
+	return "config:cache"
+}
+
+func (s ConfigCache) Description() string {
+	return "Cache the configuration files and environment variables to speed up booting."
+}
+
+func (s ConfigCache) Handle(c inter.Cli) inter.ExitCode {
+	// Without a registered loader, we get an empty loader without cache file
+	loader := c.App().Make(config.Loader{}).(config.Loader)
+
+	if s.Clear {
+		if err := loader.ClearCache(); err != nil {
+			c.Error("%s", err)
+			return inter.Failure
+		}
+		c.Info("Configuration cache cleared")
+		return inter.Success
+	}
+
+	environment, _ := c.App().Environment()
+	if err := loader.WriteCache(environment); err != nil {
+		c.Error("%s", err)
+		return inter.Failure
+	}
+	c.Info("Configuration cached in %s", loader.Cache)
+
+	return inter.Success
+}
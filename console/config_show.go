@@ -0,0 +1,77 @@
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/config"
+	"sort"
+	"strings"
+)
+
+type ConfigShow struct {
+	Key  string `short:"k" flag:"key" description:"Only show the keys that start with the key"`
+	Json bool   `flag:"json" description:"Show the configuration as JSON"`
+}
+
+func (s ConfigShow) Name() string {
+	return "config:show"
+}
+
+func (s ConfigShow) Description() string {
+	return "Show the merged configuration. Sensitive values are masked."
+}
+
+func (s ConfigShow) Handle(c inter.Cli) inter.ExitCode {
+	index, ok := c.App().Make("config").(map[string]interface{})
+	if !ok {
+		c.Error("No configuration found")
+		return inter.Failure
+	}
+
+	values := map[string]interface{}{}
+	var keys []string
+	for key, value := range config.Mask(config.Flatten(index)) {
+		if strings.HasPrefix(key, s.Key) {
+			values[key] = value
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	if s.Json {
+		return s.renderJson(c, values)
+	}
+
+	if len(keys) == 0 {
+		c.Comment("No configuration found")
+		return inter.Success
+	}
+
+	t := c.Table()
+	t.AppendHeader([]interface{}{"Key", "Value"})
+	for _, key := range keys {
+		t.AppendRow([]interface{}{key, fmt.Sprintf("%v", values[key])})
+	}
+	t.Render()
+
+	return inter.Success
+}
+
+func (s ConfigShow) renderJson(c inter.Cli, values map[string]interface{}) inter.ExitCode {
+	for key, value := range values {
+		// Values that can't be encoded (e.g. errors or loggers) are shown as text
+		if _, err := json.Marshal(value); err != nil {
+			values[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	result, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		c.Error("Can't encode configuration: %s", err)
+		return inter.Failure
+	}
+	c.Line("%s", result)
+
+	return inter.Success
+}
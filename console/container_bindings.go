@@ -0,0 +1,87 @@
+package console
+
+import (
+	"encoding/json"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
+	"strings"
+)
+
+type ContainerBindings struct {
+	Prefix string `short:"p" flag:"prefix" description:"Only show abstracts that start with the prefix"`
+	Json   bool   `flag:"json" description:"Show the bindings as JSON"`
+}
+
+func (b ContainerBindings) Name() string {
+	return "container:bindings"
+}
+
+func (b ContainerBindings) Description() string {
+	return "List the bindings of the service container."
+}
+
+func (b ContainerBindings) Handle(c inter.Cli) inter.ExitCode {
+	container, ok := (*c.App().Container()).(interface {
+		Describe() []foundation.BindingDescription
+	})
+	if !ok {
+		c.Error("The container can't describe its bindings. Use foundation.Container")
+		return inter.Failure
+	}
+
+	var descriptions []foundation.BindingDescription
+	for _, description := range container.Describe() {
+		if strings.HasPrefix(description.Abstract, b.Prefix) {
+			descriptions = append(descriptions, description)
+		}
+	}
+
+	if b.Json {
+		return b.renderJson(c, descriptions)
+	}
+
+	if len(descriptions) == 0 {
+		c.Comment("No bindings found")
+		return inter.Success
+	}
+
+	t := c.Table()
+	t.AppendHeader([]interface{}{"Abstract", "Source", "Binding", "Type"})
+	for _, description := range descriptions {
+		t.AppendRow([]interface{}{
+			description.Abstract,
+			description.Source,
+			bindingKind(description),
+			description.Type,
+		})
+	}
+	t.Render()
+
+	return inter.Success
+}
+
+func (b ContainerBindings) renderJson(c inter.Cli, descriptions []foundation.BindingDescription) inter.ExitCode {
+	if descriptions == nil {
+		descriptions = []foundation.BindingDescription{}
+	}
+
+	result, err := json.MarshalIndent(descriptions, "", "  ")
+	if err != nil {
+		c.Error("Can't encode bindings: %s", err)
+		return inter.Failure
+	}
+	c.Line("%s", result)
+
+	return inter.Success
+}
+
+func bindingKind(description foundation.BindingDescription) string {
+	switch {
+	case description.Callback:
+		return description.Lifetime + " callback"
+	case description.Lifetime != "":
+		return description.Lifetime + " value"
+	default:
+		return "value"
+	}
+}
@@ -1,12 +1,16 @@
 package console
 
 import (
+	"context"
 	"flag"
 	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
 	"github.com/confetti-framework/foundation/console/facade"
 	"github.com/confetti-framework/foundation/console/flag_type"
 	"github.com/confetti-framework/foundation/console/service"
 	"io"
+	"os"
+	"time"
 )
 
 // These values must be in a callback so that they are new
@@ -26,6 +30,18 @@ var flagGetters = func() []flag.Getter {
 
 var commands = []inter.Command{
 	Baker{},
+	ConfigCache{},
+	ConfigShow{},
+	ContainerBindings{},
+	RouteList{},
+	Serve{},
+}
+
+// SignalHandler is implemented by commands that handle SIGINT and SIGTERM
+// themselves (e.g. to stop a server gracefully). The kernel doesn't listen
+// for these signals while such a command is executed.
+type SignalHandler interface {
+	HandlesSignals() bool
 }
 
 type Kernel struct {
@@ -34,19 +50,66 @@ type Kernel struct {
 	WriterErr     io.Writer
 	Commands      []inter.Command
 	FlagProviders []func() []flag.Getter
+	// The time the application gets to terminate, 10 seconds by default
+	TerminateTimeout time.Duration
 }
 
 func (k Kernel) Handle() inter.ExitCode {
 	k.Commands = append(k.Commands, commands...)
 	k.FlagProviders = append(k.FlagProviders, flagGetters)
 
+	if booter, ok := k.App.(foundation.Booter); ok {
+		booter.Booted()
+	}
+
 	cli := facade.NewCli(k.App, k.Writer, k.WriterErr)
+
+	app, terminable := k.App.(foundation.Terminator)
+	if terminable && !k.handlesSignals() {
+		// Terminate the application gracefully when the command is interrupted
+		stop := foundation.TerminateOnSignal(app, k.terminateTimeout(), func(err error) {
+			if err != nil {
+				cli.Error("%s", err)
+				os.Exit(int(inter.Failure))
+			}
+			os.Exit(int(inter.Success))
+		})
+		defer stop()
+	}
+
 	code := service.DispatchCommands(cli, k.Commands, k.FlagProviders)
-	if code != inter.Index {
-		return code
+	if code == inter.Index {
+		code = service.RenderIndex(cli, k.Commands)
+	}
+
+	if terminable {
+		ctx, cancel := context.WithTimeout(context.Background(), k.terminateTimeout())
+		defer cancel()
+		if err := app.Terminate(ctx); err != nil {
+			cli.Error("%s", err)
+			return inter.Failure
+		}
+	}
+
+	return code
+}
+
+func (k Kernel) handlesSignals() bool {
+	command, ok := service.ActualCommand(k.App, k.Commands)
+	if !ok {
+		return false
+	}
+	handler, ok := command.(SignalHandler)
+
+	return ok && handler.HandlesSignals()
+}
+
+func (k Kernel) terminateTimeout() time.Duration {
+	if k.TerminateTimeout == 0 {
+		return 10 * time.Second
 	}
 
-	return service.RenderIndex(cli, k.Commands)
+	return k.TerminateTimeout
 }
 
 func (k Kernel) GetCommands() []inter.Command {
@@ -0,0 +1,88 @@
+package console
+
+import (
+	"encoding/json"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http/routing"
+	"strings"
+)
+
+type RouteList struct {
+	Method    string `short:"m" flag:"method" description:"Only show the routes with the method"`
+	RouteName string `short:"n" flag:"name" description:"Only show the routes of which the name contains the value"`
+	Path      string `short:"p" flag:"path" description:"Only show the routes of which the uri contains the value"`
+	Json      bool   `flag:"json" description:"Show the routes as JSON"`
+}
+
+func (r RouteList) Name() string {
+	return "route:list"
+}
+
+func (r RouteList) Description() string {
+	return "List the registered routes."
+}
+
+func (r RouteList) Handle(c inter.Cli) inter.ExitCode {
+	routes, err := c.App().MakeE("routes")
+	if err != nil {
+		c.Error("No routes found: %s", err)
+		return inter.Failure
+	}
+
+	descriptions := []routing.RouteDescription{}
+	for _, description := range routing.Describe(routes.(inter.RouteCollection)) {
+		if r.matches(description) {
+			descriptions = append(descriptions, description)
+		}
+	}
+
+	if r.Json {
+		return r.renderJson(c, descriptions)
+	}
+
+	if len(descriptions) == 0 {
+		c.Comment("No routes found")
+		return inter.Success
+	}
+
+	t := c.Table()
+	t.AppendHeader([]interface{}{"Method", "Domain", "Uri", "Name", "Constraints", "Middleware"})
+	for _, description := range descriptions {
+		t.AppendRow([]interface{}{
+			description.Method,
+			description.Domain,
+			description.Uri,
+			description.Name,
+			strings.Join(description.ConstraintList(), ", "),
+			strings.Join(description.Middleware, ", "),
+		})
+	}
+	t.Render()
+
+	return inter.Success
+}
+
+func (r RouteList) matches(description routing.RouteDescription) bool {
+	if r.Method != "" && !strings.EqualFold(description.Method, r.Method) {
+		return false
+	}
+	if r.RouteName != "" && !strings.Contains(description.Name, r.RouteName) {
+		return false
+	}
+	if r.Path != "" && !strings.Contains(description.Uri, r.Path) {
+		return false
+	}
+
+	return true
+}
+
+func (r RouteList) renderJson(c inter.Cli, descriptions []routing.RouteDescription) inter.ExitCode {
+	result, err := json.MarshalIndent(descriptions, "", "  ")
+	if err != nil {
+		c.Error("Can't encode routes: %s", err)
+		return inter.Failure
+	}
+	c.Line("%s", result)
+
+	return inter.Success
+}
@@ -0,0 +1,43 @@
+package console
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http"
+)
+
+type Serve struct {
+	Addr string `flag:"addr" description:"The address to listen on (e.g. :8080), overrides the configuration"`
+}
+
+func (s Serve) Name() string {
+	return "serve"
+}
+
+func (s Serve) Description() string {
+	return "Serve the application over HTTP until the process is stopped."
+}
+
+// The server stops gracefully on SIGINT and SIGTERM
+func (s Serve) HandlesSignals() bool {
+	return true
+}
+
+func (s Serve) Handle(c inter.Cli) inter.ExitCode {
+	server, err := http.NewServer(c.App())
+	if err != nil {
+		c.Error("%s", err)
+		return inter.Failure
+	}
+	if s.Addr != "" {
+		server.Config.Addr = s.Addr
+	}
+
+	c.Info("Server listening on %s", server.Config.Addr)
+	if err := server.ListenAndServe(); err != nil {
+		c.Error("%s", err)
+		return inter.Failure
+	}
+	c.Info("Server stopped")
+
+	return inter.Success
+}
@@ -13,6 +13,18 @@ func ActualCommandName(appArgs []string) string {
 	return ""
 }
 
+// Find the command that is requested by the arguments of the application
+func ActualCommand(app inter.App, commands []inter.Command) (inter.Command, bool) {
+	name := ActualCommandName(actualArgs(app))
+	for _, command := range commands {
+		if command.Name() == name {
+			return command, true
+		}
+	}
+
+	return nil, false
+}
+
 // actualArgs converts arguments from config []interface{} to []string
 func actualArgs(app inter.App) []string {
 	var result []string
@@ -5,8 +5,36 @@ import (
 	"github.com/confetti-framework/errors"
 	"github.com/confetti-framework/support"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 )
 
+// Lifetime determines how long the result of a callback will be reused.
+type Lifetime int
+
+const (
+	// The callback will be executed once. The result is shared across all requests.
+	LifetimeSingleton Lifetime = iota
+	// The callback will be executed once per request container.
+	LifetimeScoped
+	// The callback will be executed every time the abstract is resolved.
+	LifetimeTransient
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case LifetimeSingleton:
+		return "singleton"
+	case LifetimeScoped:
+		return "scoped"
+	case LifetimeTransient:
+		return "transient"
+	}
+
+	return "unknown"
+}
+
 type Container struct {
 	// The container created at boot time
 	bootContainer inter.Container
@@ -15,14 +43,99 @@ type Container struct {
 	bindings inter.Bindings
 
 	// A key value store. If the value is a callback, it will be executed
-	// once per request and the result will be saved.
+	// once and the result will be shared across all requests.
 	singletons inter.Bindings
+
+	// A key value store. If the value is a callback, it will be executed
+	// once per request and the result will be saved.
+	scoped inter.Bindings
+
+	// A key value store. If the value is a callback, it will be executed
+	// every time the abstract is resolved.
+	transients inter.Bindings
+
+	// The results of the singleton and scoped callbacks.
+	instances inter.Bindings
+
+	// The concretes per consumer, keyed by the abstract that the consumer needs
+	contextual map[string]inter.Bindings
+
+	// The abstracts per tag
+	tags map[string][]string
+
+	// Registrations that are executed the first time one of their abstracts is resolved
+	deferred map[string]*deferredRegistration
+
+	// Guards the maps above, so the container can be used by multiple goroutines
+	mutex sync.RWMutex
+
+	// A lock per abstract, to ensure that a callback is executed only once
+	// while other goroutines wait for the result.
+	creating map[string]*sync.Mutex
+
+	// Ensures that extending an abstract is not interrupted by another extend
+	extending sync.Mutex
+}
+
+// ContextualBinding is used to define which concrete a consumer needs.
+type ContextualBinding struct {
+	container *Container
+	consumer  string
+	abstract  string
+}
+
+// Define the abstract that the consumer needs.
+func (b ContextualBinding) Needs(abstract interface{}) ContextualBinding {
+	b.abstract = support.Name(abstract)
+
+	return b
+}
+
+// Define the concrete that will be given to the consumer. If the
+// concrete is a callback, it will be executed every time the consumer is built.
+func (b ContextualBinding) Give(concrete interface{}) {
+	if b.abstract == "" {
+		panic(errors.New("can't give a contextual binding to " + b.consumer + ". Use Needs to define the abstract"))
+	}
+
+	b.container.mutex.Lock()
+	defer b.container.mutex.Unlock()
+
+	if _, ok := b.container.contextual[b.consumer]; !ok {
+		b.container.contextual[b.consumer] = inter.Bindings{}
+	}
+	b.container.contextual[b.consumer][b.abstract] = concrete
+}
+
+type deferredRegistration struct {
+	// Held while registering, so other goroutines wait for the bindings
+	mutex      sync.Mutex
+	registered bool
+	register   func(container inter.Container) inter.Container
+	boot       func(container inter.Container)
+}
+
+// resolving keeps track of the abstracts that are being resolved
+// to get to the current abstract.
+type resolving struct {
+	// The abstracts to detect circular dependencies
+	path []string
+
+	// Whether a singleton depends on the current abstract
+	singleton bool
 }
 
 func NewContainer() *Container {
 	containerStruct := Container{}
 	containerStruct.bindings = make(inter.Bindings)
 	containerStruct.singletons = make(inter.Bindings)
+	containerStruct.scoped = make(inter.Bindings)
+	containerStruct.transients = make(inter.Bindings)
+	containerStruct.instances = make(inter.Bindings)
+	containerStruct.creating = make(map[string]*sync.Mutex)
+	containerStruct.contextual = make(map[string]inter.Bindings)
+	containerStruct.tags = make(map[string][]string)
+	containerStruct.deferred = make(map[string]*deferredRegistration)
 
 	return &containerStruct
 }
@@ -44,23 +157,128 @@ func NewTestApp(decorator func(container inter.Container) inter.Container) inter
 
 // Determine if the given abstract type has been bound.
 func (c *Container) Bound(abstract string) bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
 	_, bound := c.bindings[abstract]
-	_, boundSingleton := c.singletons[abstract]
-	return bound || boundSingleton
+	_, hasLifetime := c.lifetime(abstract)
+	_, isDeferred := c.deferred[abstract]
+	return bound || hasLifetime || isDeferred
 }
 
 // Register a binding with the container.
 func (c *Container) Bind(abstract interface{}, concrete interface{}) {
 	abstractString := support.Name(abstract)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.forget(abstractString)
 	c.bindings[abstractString] = concrete
 }
 
-// Register a shared binding in the container.
+// Register a shared binding in the container. A callback will be
+// executed once, the result is shared across all requests. A callback of the
+// container created at boot time is executed in that container, so it can't
+// depend on the bindings of a request. Before lifetimes existed, such a callback
+// was executed for every request: use Scoped for a result per request (e.g.
+// the current user).
 func (c *Container) Singleton(abstract interface{}, concrete interface{}) {
 	abstractString := support.Name(abstract)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.forget(abstractString)
 	c.singletons[abstractString] = concrete
 }
 
+// Register a binding that is shared within one request. A callback will be
+// executed once for every container created by NewContainerByBoot.
+func (c *Container) Scoped(abstract interface{}, concrete interface{}) {
+	abstractString := support.Name(abstract)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.forget(abstractString)
+	c.scoped[abstractString] = concrete
+}
+
+// Register a binding that is never shared. A callback will be
+// executed every time the abstract is resolved.
+func (c *Container) Transient(abstract interface{}, concrete interface{}) {
+	abstractString := support.Name(abstract)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.forget(abstractString)
+	c.transients[abstractString] = concrete
+}
+
+// Define a contextual binding: when building the consumer, give
+// it another concrete than the one that is bound to the abstract.
+//
+//	container.When(ReportController{}).Needs((*Storage)(nil)).Give(s3Storage)
+func (c *Container) When(consumer interface{}) ContextualBinding {
+	return ContextualBinding{container: c, consumer: support.Name(consumer)}
+}
+
+// Assign tags to the given abstracts, so they can be resolved together.
+func (c *Container) Tag(abstracts []interface{}, tags ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, tag := range tags {
+		for _, abstract := range abstracts {
+			c.tags[tag] = append(c.tags[tag], support.Name(abstract))
+		}
+	}
+}
+
+// Resolve all abstracts of the given tag.
+func (c *Container) Tagged(tag string) []interface{} {
+	concretes, err := c.TaggedE(tag)
+	if err != nil {
+		panic(err)
+	}
+	return concretes
+}
+
+// Resolve all abstracts of the given tag or give an error.
+func (c *Container) TaggedE(tag string) ([]interface{}, error) {
+	//goland:noinspection GoPreferNilSlice
+	concretes := []interface{}{}
+	for _, abstractName := range c.taggedAbstracts(tag) {
+		concrete, err := c.MakeE(abstractName)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolve tag '%s'", tag)
+		}
+		concretes = append(concretes, concrete)
+	}
+
+	return concretes, nil
+}
+
+// Defer the registration of the given abstracts. The register callback will be
+// executed the first time one of the abstracts is resolved from the container.
+// After that, the boot callback (if any) receives the container returned by
+// register. Boot may resolve the abstracts of the same registration.
+func (c *Container) Defer(
+	abstracts []interface{},
+	register func(container inter.Container) inter.Container,
+	boot func(container inter.Container),
+) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	registration := &deferredRegistration{register: register, boot: boot}
+	for _, abstract := range abstracts {
+		c.deferred[support.Name(abstract)] = registration
+	}
+}
+
 // Register an existing instance as shared in the container without an abstract
 func (c *Container) Instance(concrete interface{}) interface{} {
 	c.Bind(concrete, concrete)
@@ -76,17 +294,91 @@ func (c *Container) Bindings() inter.Bindings {
 		result = c.bootContainer.Bindings()
 	}
 
-	for abstract, concrete := range c.singletons {
-		result[abstract] = concrete
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for _, registered := range []inter.Bindings{c.transients, c.scoped, c.singletons, c.bindings} {
+		for abstract, concrete := range registered {
+			result[abstract] = concrete
+		}
+	}
+
+	return result
+}
+
+// BindingDescription describes how an abstract is registered in the container.
+type BindingDescription struct {
+	Abstract string `json:"abstract"`
+
+	// "boot" if registered in the container created at boot time, otherwise "request"
+	Source string `json:"source"`
+
+	// The lifetime of a singleton, scoped or transient binding. Empty for plain bindings.
+	Lifetime string `json:"lifetime"`
+
+	// Whether the concrete is a callback that will be executed to resolve the abstract
+	Callback bool `json:"callback"`
+
+	// The Go type the abstract resolves to
+	Type string `json:"type"`
+}
+
+// Describe all abstracts in the container and the container created at boot time.
+// Callbacks are not executed, the type is derived from the return value.
+func (c *Container) Describe() []BindingDescription {
+	descriptions := map[string]BindingDescription{}
+
+	if bootContainer, ok := c.bootContainer.(*Container); ok {
+		for _, description := range bootContainer.Describe() {
+			descriptions[description.Abstract] = description
+		}
+	} else if c.bootContainer != nil {
+		for abstract, concrete := range c.bootContainer.Bindings() {
+			descriptions[abstract] = describe(abstract, concrete, "boot", "")
+		}
+	}
+
+	source := "boot"
+	if c.bootContainer != nil {
+		source = "request"
 	}
 
+	c.mutex.RLock()
+	for _, lifetime := range []Lifetime{LifetimeTransient, LifetimeScoped, LifetimeSingleton} {
+		for abstract, concrete := range c.registered(lifetime) {
+			descriptions[abstract] = describe(abstract, concrete, source, lifetime.String())
+		}
+	}
 	for abstract, concrete := range c.bindings {
-		result[abstract] = concrete
+		descriptions[abstract] = describe(abstract, concrete, source, "")
 	}
+	c.mutex.RUnlock()
+
+	var result []BindingDescription
+	for _, description := range descriptions {
+		result = append(result, description)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Abstract < result[j].Abstract
+	})
 
 	return result
 }
 
+// Lifetime determines how long the result of the callback of the abstract
+// will be reused. The second value is false for plain bindings.
+func (c *Container) Lifetime(abstract interface{}) (Lifetime, bool) {
+	abstractName := support.Name(abstract)
+	if lifetime, _, ok := c.registration(abstractName); ok {
+		return lifetime, true
+	}
+	if bootContainer, ok := c.bootContainer.(*Container); ok {
+		return bootContainer.Lifetime(abstractName)
+	}
+
+	return 0, false
+}
+
 // MakeE the given type from the container.
 func (c *Container) Make(abstract interface{}) interface{} {
 	concrete, err := c.MakeE(abstract)
@@ -98,9 +390,12 @@ func (c *Container) Make(abstract interface{}) interface{} {
 
 // MakeE the given type from the container.
 func (c *Container) MakeE(abstract interface{}) (interface{}, error) {
+	return c.make(abstract, resolving{})
+}
+
+func (c *Container) make(abstract interface{}, r resolving) (interface{}, error) {
 	var concrete interface{}
 	var err error = nil
-	var abstractName = support.Name(abstract)
 
 	kind := support.Kind(abstract)
 	if support.Kind(abstract) == reflect.Ptr && abstract == nil {
@@ -108,23 +403,37 @@ func (c *Container) MakeE(abstract interface{}) (interface{}, error) {
 			"use the following syntax: (*interface)(nil), use a string or use the struct itself")
 	}
 
-	if object, present := c.bindings[abstractName]; present {
-		concrete = object
+	var abstractName = support.Name(abstract)
+	if containsString(r.path, abstractName) {
+		return nil, CircularDependencyError.Wrap(strings.Join(append(r.path, abstractName), " -> "))
+	}
+
+	c.registerDeferred(abstractName)
+
+	c.mutex.RLock()
+	instance, hasInstance := c.instances[abstractName]
+	binding, hasBinding := c.bindings[abstractName]
+	c.mutex.RUnlock()
+
+	if hasInstance {
+		concrete = instance
 
-	} else if object, present := c.singletons[abstractName]; present {
-		concrete, err = c.getConcreteBinding(concrete, object, abstractName)
+	} else if hasBinding {
+		concrete = binding
+
+	} else if lifetime, object, present := c.registration(abstractName); present {
+		concrete, err = c.getConcreteBinding(lifetime, object, abstractName, r)
 
 	} else if c.bootContainer != nil && c.bootContainer.Bound(abstractName) {
 		// Check the container that was created at boot time
-		concrete, err = c.bootContainer.MakeE(abstract)
-		c.bindings[abstractName] = concrete
+		concrete, err = c.makeFromBoot(abstract, abstractName, r)
 
 	} else if kind == reflect.Struct {
 		// If struct cannot be found, we simply have to use the struct itself.
 		concrete = abstract
 	} else if kind == reflect.String {
 		var instances support.Map
-		instances, err = support.NewMapE(c.bindings)
+		instances, err = support.NewMapE(c.copyBindings())
 		if err == nil {
 			var value support.Value
 			if c.bootContainer != nil {
@@ -151,32 +460,220 @@ func (c *Container) MakeE(abstract interface{}) (interface{}, error) {
 }
 
 func (c *Container) getConcreteBinding(
-	concrete interface{},
+	lifetime Lifetime,
 	object interface{},
 	abstractName string,
+	r resolving,
 ) (interface{}, error) {
+	// A singleton can't hold an instance that only lives as long as one request
+	if lifetime == LifetimeScoped && r.singleton {
+		return nil, SingletonDependsOnScopedError.Wrap(
+			"singleton '%s' depends on scoped '%s'",
+			r.path[len(r.path)-1],
+			abstractName,
+		)
+	}
+
+	// Only one goroutine may execute the callback. The others wait for the result.
+	if lifetime != LifetimeTransient {
+		creating := c.lockCreating(abstractName)
+		creating.Lock()
+		defer creating.Unlock()
+
+		c.mutex.RLock()
+		instance, created := c.instances[abstractName]
+		c.mutex.RUnlock()
+		if created {
+			return instance, nil
+		}
+	}
+
 	// If abstract is bound, use that object.
-	concrete = object
+	concrete := object
 	value := reflect.ValueOf(concrete)
 
-	// If concrete is a callback, run it and save the result.
+	// If concrete is a callback, resolve the parameters and run it.
 	if value.Kind() == reflect.Func {
-		if value.Type().NumIn() != 0 {
-			return nil, errors.WithStack(CanNotInstantiateCallbackWithParameters)
+		dependencies := resolving{
+			path:      withResolving(r.path, abstractName),
+			singleton: r.singleton || lifetime == LifetimeSingleton,
+		}
+		arguments, err := c.resolveArguments(value.Type(), dependencies)
+		if err != nil {
+			return nil, err
 		}
-		concrete = value.Call([]reflect.Value{})[0].Interface()
+		concrete = value.Call(arguments)[0].Interface()
 	}
 
-	// Don't save result in bootContainer. We don't want to share the result across multiple requests
-	if c.bootContainer != nil {
-		c.bindings[abstractName] = concrete
+	// Save the result, unless it should be created every time
+	if lifetime != LifetimeTransient {
+		c.mutex.Lock()
+		c.instances[abstractName] = concrete
+		c.mutex.Unlock()
 	}
 
 	return concrete, nil
 }
 
+// Resolve the parameters of a callback from the container. The last
+// abstract in the path is the abstract that the callback belongs to.
+func (c *Container) resolveArguments(callback reflect.Type, r resolving) ([]reflect.Value, error) {
+	if callback.IsVariadic() {
+		return nil, errors.WithStack(CanNotInstantiateCallbackWithParameters)
+	}
+
+	var arguments []reflect.Value
+	for i := 0; i < callback.NumIn(); i++ {
+		parameter := callback.In(i)
+		argument, err := c.resolveArgument(parameter, r)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolve parameter %d (%s) of '%s'", i, parameter, r.path[len(r.path)-1])
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return arguments, nil
+}
+
+func (c *Container) resolveArgument(parameter reflect.Type, r resolving) (reflect.Value, error) {
+	// A callback can ask for the container itself
+	if parameter == containerType {
+		return reflect.ValueOf(inter.Container(c)), nil
+	}
+
+	abstract := abstractByType(parameter)
+	abstractName := support.Name(abstract)
+
+	consumer := r.path[len(r.path)-1]
+	if concrete, ok := c.contextualConcrete(consumer, abstractName); ok {
+		return c.resolveContextual(concrete, abstractName, parameter, r)
+	}
+
+	if !c.resolvable(abstractName, parameter) {
+		return reflect.Value{}, CanNotResolveDependencyError.Wrap("no binding found for %s", abstractName)
+	}
+
+	concrete, err := c.make(abstract, r)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return toArgument(concrete, abstractName, parameter)
+}
+
+// Resolve the concrete of a contextual binding. A callback will be executed every time.
+func (c *Container) resolveContextual(
+	concrete interface{},
+	abstractName string,
+	parameter reflect.Type,
+	r resolving,
+) (reflect.Value, error) {
+	value := reflect.ValueOf(concrete)
+	if value.Kind() == reflect.Func && parameter.Kind() != reflect.Func {
+		arguments, err := c.resolveArguments(value.Type(), resolving{
+			path:      withResolving(r.path, abstractName),
+			singleton: r.singleton,
+		})
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		concrete = value.Call(arguments)[0].Interface()
+	}
+
+	return toArgument(concrete, abstractName, parameter)
+}
+
+// Get the concrete that is given to the consumer when it needs the abstract.
+func (c *Container) contextualConcrete(consumer string, abstractName string) (interface{}, bool) {
+	c.mutex.RLock()
+	concrete, ok := c.contextual[consumer][abstractName]
+	c.mutex.RUnlock()
+	if ok {
+		return concrete, true
+	}
+
+	if bootContainer, ok := c.bootContainer.(*Container); ok {
+		return bootContainer.contextualConcrete(consumer, abstractName)
+	}
+
+	return nil, false
+}
+
+// Get the abstracts of a tag, starting with the abstracts from the container created at boot time.
+func (c *Container) taggedAbstracts(tag string) []string {
+	var result []string
+	if bootContainer, ok := c.bootContainer.(*Container); ok {
+		result = bootContainer.taggedAbstracts(tag)
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for _, abstractName := range c.tags[tag] {
+		if !containsString(result, abstractName) {
+			result = append(result, abstractName)
+		}
+	}
+
+	return result
+}
+
+func toArgument(concrete interface{}, abstractName string, parameter reflect.Type) (reflect.Value, error) {
+	if concrete == nil {
+		return reflect.Zero(parameter), nil
+	}
+
+	value := reflect.ValueOf(concrete)
+	if !value.Type().AssignableTo(parameter) {
+		return reflect.Value{}, CanNotResolveDependencyError.Wrap(
+			"'%s' resolves to %s, which is not assignable to %s",
+			abstractName,
+			value.Type(),
+			parameter,
+		)
+	}
+
+	return value, nil
+}
+
+// Determine if a parameter can be resolved. Only bound abstracts and
+// structs can be resolved. Otherwise, we would resolve a string
+// parameter with all bindings.
+func (c *Container) resolvable(abstractName string, parameter reflect.Type) bool {
+	if c.Bound(abstractName) {
+		return true
+	}
+	if c.bootContainer != nil && c.bootContainer.Bound(abstractName) {
+		return true
+	}
+
+	return parameter.Kind() == reflect.Struct
+}
+
+func (c *Container) makeFromBoot(abstract interface{}, abstractName string, r resolving) (interface{}, error) {
+	bootContainer, ok := c.bootContainer.(*Container)
+	if !ok {
+		return c.bootContainer.MakeE(abstract)
+	}
+	bootContainer.registerDeferred(abstractName)
+
+	// Scoped and transient callbacks are executed in this container. That way,
+	// the result is not shared with other requests and the callback can
+	// depend on bindings of this request.
+	lifetime, object, present := bootContainer.registration(abstractName)
+	if present && lifetime != LifetimeSingleton {
+		return c.getConcreteBinding(lifetime, object, abstractName, r)
+	}
+
+	// Pass the path to detect circular dependencies across both containers
+	return bootContainer.make(abstract, r)
+}
+
 // Extend an abstract type in the container.
 func (c *Container) Extend(abstract interface{}, function func(service interface{}) interface{}) {
+	c.extending.Lock()
+	defer c.extending.Unlock()
+
 	concrete := c.Make(abstract)
 
 	newConcrete := function(concrete)
@@ -184,6 +681,175 @@ func (c *Container) Extend(abstract interface{}, function func(service interface
 	c.Bind(abstract, newConcrete)
 }
 
+// Get the lifetime and the concrete of an abstract registered in this container.
+func (c *Container) registration(abstractName string) (Lifetime, interface{}, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	lifetime, present := c.lifetime(abstractName)
+	if !present {
+		return 0, nil, false
+	}
+
+	return lifetime, c.registered(lifetime)[abstractName], true
+}
+
+// Get the lifetime of an abstract registered in this container. The caller must hold the mutex.
+func (c *Container) lifetime(abstractName string) (Lifetime, bool) {
+	for _, lifetime := range []Lifetime{LifetimeSingleton, LifetimeScoped, LifetimeTransient} {
+		if _, present := c.registered(lifetime)[abstractName]; present {
+			return lifetime, true
+		}
+	}
+
+	return 0, false
+}
+
+func (c *Container) registered(lifetime Lifetime) inter.Bindings {
+	switch lifetime {
+	case LifetimeScoped:
+		return c.scoped
+	case LifetimeTransient:
+		return c.transients
+	default:
+		return c.singletons
+	}
+}
+
+// Execute the deferred registration of the abstract. All other
+// abstracts of the same registration are no longer deferred. The
+// registration is booted after that, so resolving an abstract of the
+// same registration from boot doesn't wait for the registration itself.
+func (c *Container) registerDeferred(abstractName string) {
+	c.mutex.RLock()
+	registration, isDeferred := c.deferred[abstractName]
+	c.mutex.RUnlock()
+	if !isDeferred {
+		return
+	}
+
+	registration.mutex.Lock()
+	if registration.registered {
+		registration.mutex.Unlock()
+		return
+	}
+	container := registration.register(c)
+	registration.registered = true
+
+	var abstracts []string
+	c.mutex.Lock()
+	for abstract, deferred := range c.deferred {
+		if deferred == registration {
+			delete(c.deferred, abstract)
+			abstracts = append(abstracts, abstract)
+		}
+	}
+	c.mutex.Unlock()
+
+	// When register returns another container, the abstracts
+	// that are not bound here are resolved from that container.
+	if container != inter.Container(c) {
+		for _, abstract := range abstracts {
+			abstract := abstract
+			if !c.Bound(abstract) {
+				c.Transient(abstract, func() interface{} { return container.Make(abstract) })
+			}
+		}
+	}
+	registration.mutex.Unlock()
+
+	if registration.boot != nil {
+		registration.boot(container)
+	}
+}
+
+// Get the lock for creating the instance of an abstract.
+func (c *Container) lockCreating(abstractName string) *sync.Mutex {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	lock, ok := c.creating[abstractName]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.creating[abstractName] = lock
+	}
+
+	return lock
+}
+
+func (c *Container) copyBindings() inter.Bindings {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	result := make(inter.Bindings, len(c.bindings))
+	for abstract, concrete := range c.bindings {
+		result[abstract] = concrete
+	}
+
+	return result
+}
+
+// Remove the previous registration, so an abstract can be registered
+// with another lifetime. The caller must hold the mutex.
+func (c *Container) forget(abstractName string) {
+	delete(c.bindings, abstractName)
+	delete(c.singletons, abstractName)
+	delete(c.scoped, abstractName)
+	delete(c.transients, abstractName)
+	delete(c.instances, abstractName)
+	delete(c.deferred, abstractName)
+}
+
+func describe(abstract string, concrete interface{}, source string, lifetime string) BindingDescription {
+	description := BindingDescription{Abstract: abstract, Source: source, Lifetime: lifetime, Type: "nil"}
+	if concrete == nil {
+		return description
+	}
+
+	concreteType := reflect.TypeOf(concrete)
+	description.Type = concreteType.String()
+
+	// Plain bindings return the callback itself
+	if concreteType.Kind() == reflect.Func && lifetime != "" {
+		description.Callback = true
+		if concreteType.NumOut() > 0 {
+			description.Type = concreteType.Out(0).String()
+		}
+	}
+
+	return description
+}
+
+var containerType = reflect.TypeOf((*inter.Container)(nil)).Elem()
+
+// Get the abstract as if the type would be registered. An interface is
+// registered with (*interface)(nil), other types by their (zero) value.
+func abstractByType(parameter reflect.Type) interface{} {
+	if parameter.Kind() == reflect.Interface {
+		return reflect.Zero(reflect.PtrTo(parameter)).Interface()
+	}
+
+	return reflect.Zero(parameter).Interface()
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, value := range haystack {
+		if value == needle {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add the abstract to a copy of the path, so paths of other parameters don't share the same array.
+func withResolving(path []string, abstractName string) []string {
+	result := make([]string, len(path), len(path)+1)
+	copy(result, path)
+
+	return append(result, abstractName)
+}
+
 func resolvePointerValue(abstract interface{}, concrete interface{}) {
 	if support.Kind(abstract) == reflect.Ptr {
 		of := reflect.ValueOf(abstract)
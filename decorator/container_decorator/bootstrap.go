@@ -2,10 +2,28 @@ package container_decorator
 
 import (
 	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/support"
 )
 
+// A deferred provider is only registered the first time
+// one of the abstracts it provides is resolved.
+type DeferrableProvider interface {
+	inter.RegisterServiceProvider
+	Provides() []interface{}
+}
+
+type deferrer interface {
+	Defer(abstracts []interface{}, register func(container inter.Container) inter.Container, boot func(container inter.Container))
+}
+
 type Handler struct {
 	Bootstraps []inter.Bootstrap
+
+	// The providers implement inter.RegisterServiceProvider, inter.BootServiceProvider
+	// or both. First, all providers will be registered. After that, all
+	// providers will be booted with access to all registered bindings.
+	Providers []interface{}
 }
 
 func (d Handler) BootstrapWith(container inter.Container) inter.Container {
@@ -13,5 +31,56 @@ func (d Handler) BootstrapWith(container inter.Container) inter.Container {
 		container = bootstrapper.Bootstrap(container)
 	}
 
+	container = d.registerProviders(container)
+	container = d.bootProviders(container)
+
+	return container
+}
+
+func (d Handler) registerProviders(container inter.Container) inter.Container {
+	for _, provider := range d.Providers {
+		switch provider := provider.(type) {
+		case DeferrableProvider:
+			deferProvider(container, provider)
+		case inter.RegisterServiceProvider:
+			container = provider.Register(container)
+		case inter.BootServiceProvider:
+		default:
+			panic(errors.New("provider " + support.Name(provider) + " has no Register or Boot method"))
+		}
+	}
+
 	return container
 }
+
+func (d Handler) bootProviders(container inter.Container) inter.Container {
+	for _, provider := range d.Providers {
+		if _, ok := provider.(DeferrableProvider); ok {
+			continue
+		}
+		if provider, ok := provider.(inter.BootServiceProvider); ok {
+			container = provider.Boot(container)
+		}
+	}
+
+	return container
+}
+
+// A deferred provider is registered and booted at once when needed. At
+// that time, all other providers are already registered. Boot receives
+// the container returned by Register.
+func deferProvider(container inter.Container, provider DeferrableProvider) {
+	deferrable, ok := container.(deferrer)
+	if !ok {
+		panic(errors.New("can't defer provider " + support.Name(provider) + ". The container does not support deferred providers"))
+	}
+
+	var boot func(container inter.Container)
+	if provider, ok := provider.(inter.BootServiceProvider); ok {
+		boot = func(container inter.Container) {
+			provider.Boot(container)
+		}
+	}
+
+	deferrable.Defer(provider.Provides(), provider.Register, boot)
+}
@@ -14,9 +14,9 @@ func (c ConstrainParameters) Decorate(route inter.Route) inter.Route {
 	}
 
 	for parameter, constrainRegex := range route.Constraint() {
-		oldMatch := "{" + parameter + "}"
-		newMatch := "{" + parameter + ":" + constrainRegex + "}"
-		uri = strings.Replace(uri, oldMatch, newMatch, 10)
+		uri = strings.ReplaceAll(uri, "{"+parameter+"}", "{"+parameter+":"+constrainRegex+"}")
+		// An optional parameter may also be empty
+		uri = strings.ReplaceAll(uri, "{"+parameter+"?}", "{"+parameter+":(?:"+constrainRegex+")?}")
 	}
 
 	return route.SetUri(uri)
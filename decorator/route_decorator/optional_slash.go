@@ -5,7 +5,8 @@ import (
 	"strings"
 )
 
-const optionalSlash = "{allow_slash:\\/?}"
+// OptionalSlash allows a trailing slash at the end of the uri
+const OptionalSlash = "{allow_slash:\\/?}"
 
 type UriSuffixSlash struct{}
 
@@ -13,7 +14,7 @@ func (o UriSuffixSlash) Decorate(route inter.Route) inter.Route {
 	uri := route.Uri()
 
 	if !strings.Contains(uri, "?") && !strings.HasSuffix(uri, "/") {
-		route.SetUri(uri + optionalSlash)
+		route.SetUri(uri + OptionalSlash)
 	}
 
 	return route
@@ -23,7 +24,7 @@ func (o UriSuffixSlash) Decorate(route inter.Route) inter.Route {
 func (o UriSuffixSlash) Revert(route inter.Route) inter.Route {
 	uri := route.Uri()
 
-	uri = strings.ReplaceAll(uri, optionalSlash, "")
+	uri = strings.ReplaceAll(uri, OptionalSlash, "")
 
 	return route.SetUri(uri)
 }
@@ -3,3 +3,13 @@ package foundation
 import "github.com/confetti-framework/errors"
 
 var CanNotInstantiateCallbackWithParameters = errors.New("Can not instantiate callback with parameters")
+
+var CanNotResolveDependencyError = errors.New("can not resolve dependency")
+
+var CircularDependencyError = errors.New("circular dependency detected")
+
+var SingletonDependsOnScopedError = errors.New("a singleton can't depend on a scoped binding")
+
+var TerminateTimeoutError = errors.New("the application could not terminate before the deadline")
+
+var ApplicationTerminatingError = errors.New("the application is terminating")
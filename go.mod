@@ -3,12 +3,14 @@ module github.com/confetti-framework/foundation
 go 1.15
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/confetti-framework/baker v1.1.1
 	github.com/confetti-framework/contract v0.2.1
 	github.com/confetti-framework/errors v0.11.0
 	github.com/confetti-framework/support v0.2.3
 	github.com/confetti-framework/syslog v0.1.1
 	github.com/gorilla/mux v1.8.0
+	github.com/gorilla/websocket v1.4.2
 	github.com/jedib0t/go-pretty/v6 v6.1.0
 	github.com/manifoldco/promptui v0.8.0
 	github.com/pkg/errors v0.9.1
@@ -21,4 +23,5 @@ require (
 	golang.org/x/sys v0.0.0-20210228012217-479acdf4ea46 // indirect
 	golang.org/x/term v0.0.0-20210220032956-6a3ed077a48d // indirect
 	golang.org/x/text v0.3.5
+	gopkg.in/yaml.v3 v3.0.1
 )
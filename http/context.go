@@ -0,0 +1,23 @@
+package http
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+)
+
+// ContextRequest is implemented by requests that carry a context.
+type ContextRequest interface {
+	Context() context.Context
+	SetContext(ctx context.Context) inter.Request
+	WithContext(ctx context.Context) inter.Request
+}
+
+// Receive the context of the request. A request without a
+// context receives the background context.
+func Context(request inter.Request) context.Context {
+	if contextRequest, ok := request.(ContextRequest); ok {
+		return contextRequest.Context()
+	}
+
+	return context.Background()
+}
@@ -2,11 +2,34 @@ package http
 
 import (
 	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
 	net "net/http"
 	"strings"
 )
 
+// requestTracker is implemented by applications that wait for
+// in-flight requests before they terminate.
+type requestTracker interface {
+	StartRequest() (func(), error)
+}
+
 func HandleHttpKernel(app inter.App, response net.ResponseWriter, request *net.Request) {
+	if tracker, ok := app.(requestTracker); ok {
+		done, err := tracker.StartRequest()
+		// The application is terminating, so the request can't be handled anymore
+		if err != nil {
+			response.Header().Set("Connection", "close")
+			net.Error(response, net.StatusText(net.StatusServiceUnavailable), net.StatusServiceUnavailable)
+			return
+		}
+		defer done()
+	}
+
+	// Normally, the application has booted before the server starts
+	if booter, ok := app.(foundation.Booter); ok {
+		booter.Booted()
+	}
+
 	/*
 	   |--------------------------------------------------------------------------
 	   | Register The Response Writer
@@ -38,17 +61,36 @@ func HandleHttpKernel(app inter.App, response net.ResponseWriter, request *net.R
 
 	defer func() {
 		if rec := recover(); rec != nil {
+			// A failed stream can't be converted to a response anymore
+			if rec == net.ErrAbortHandler {
+				panic(rec)
+			}
+			// Nobody is listening anymore
+			if request.Context().Err() != nil {
+				return
+			}
 			appResponse := kernel.RecoverFromMiddlewarePanic(rec)
-			exposeResponse(response, appResponse)
+			exposeResponse(response, request, appResponse)
 		}
 	}()
 
 	appResponse := kernel.Handle(appRequest)
 
-	exposeResponse(response, appResponse)
+	// The client closed the connection, so the response can't be sent
+	if request.Context().Err() != nil {
+		return
+	}
+
+	exposeResponse(response, request, appResponse)
 }
 
-func exposeResponse(response net.ResponseWriter, appResponse inter.Response) {
+func exposeResponse(response net.ResponseWriter, request *net.Request, appResponse inter.Response) {
+	// Responses that write the body themselves (e.g. streams and files)
+	if handler, ok := appResponse.(net.Handler); ok {
+		handler.ServeHTTP(response, request)
+		return
+	}
+
 	// Add HTTP headers
 	for key, values := range appResponse.GetHeaders() {
 		response.Header().Add(key, strings.Join(values, "; "))
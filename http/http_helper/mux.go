@@ -6,12 +6,18 @@ import (
 )
 
 func MuxFromRoute(route inter.Route) *mux.Route {
+	return MuxFromRouteWithUri(route, route.Uri())
+}
+
+// Build the mux route with another uri than the uri of the route (e.g.
+// without decorations). The route itself is never changed.
+func MuxFromRouteWithUri(route inter.Route, uri string) *mux.Route {
 	muxRoute := new(mux.Route)
 	for _, prefix := range route.RouteOptions().Prefixes() {
 		muxRoute.PathPrefix(prefix)
 	}
 
-	muxRoute.Path(route.Uri())
+	muxRoute.Path(uri)
 	if route.Domain() != "" {
 		muxRoute.Host(route.Domain())
 	}
@@ -0,0 +1,36 @@
+package http_helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+)
+
+// The query parameters of a signed URL
+const (
+	SignatureParameter = "signature"
+	ExpiresParameter   = "expires"
+)
+
+// Create the HMAC signature of the scheme, host, path and query of the URL.
+// The query is sorted by key and the signature parameter itself is not signed.
+func Signature(key string, target *url.URL) string {
+	unsigned := url.Values{}
+	for name, values := range target.Query() {
+		if name != SignatureParameter {
+			unsigned[name] = values
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(target.Scheme + "://" + target.Host + target.EscapedPath() + "?" + unsigned.Encode()))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Determine if the signature in the query belongs to the URL.
+func HasValidSignature(key string, target *url.URL) bool {
+	expected := Signature(key, target)
+	return hmac.Equal([]byte(expected), []byte(target.Query().Get(SignatureParameter)))
+}
@@ -0,0 +1,79 @@
+package http_helper
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/spf13/cast"
+	network "net"
+	"strings"
+)
+
+// Receive the scheme the client used for the request. When a proxy has
+// terminated TLS, the original scheme is taken from X-Forwarded-Proto. Any
+// client can send that header, so it is only trusted when the request comes
+// from one of the proxies in config.Http.TrustedProxies of the application
+// (IP addresses or CIDR ranges, e.g. "10.0.0.0/8").
+func Scheme(app inter.AppReader, request inter.Request) string {
+	source := request.Source()
+	if source.TLS != nil {
+		return "https"
+	}
+	if strings.EqualFold(request.Header("X-Forwarded-Proto"), "https") && fromTrustedProxy(app, source.RemoteAddr) {
+		return "https"
+	}
+
+	return "http"
+}
+
+// Receive the host (and port) the client requested.
+func Host(request inter.Request) string {
+	source := request.Source()
+	if source.Host != "" {
+		return source.Host
+	}
+
+	return source.URL.Host
+}
+
+func fromTrustedProxy(app inter.AppReader, remoteAddr string) bool {
+	raw, err := app.MakeE("config.Http.TrustedProxies")
+	if err != nil {
+		return false
+	}
+
+	host, _, err := network.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := network.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies(raw) {
+		if _, cidr, err := network.ParseCIDR(proxy); err == nil {
+			if cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if ip.Equal(network.ParseIP(proxy)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The proxies can be configured as a list or as a comma separated string
+// (e.g. from an environment variable).
+func trustedProxies(raw interface{}) []string {
+	var result []string
+	if proxies, ok := raw.(string); ok {
+		for _, proxy := range strings.Split(proxies, ",") {
+			result = append(result, strings.TrimSpace(proxy))
+		}
+		return result
+	}
+
+	return cast.ToStringSlice(raw)
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+)
+
+// contextRequest is implemented by requests that carry a context.
+type contextRequest interface {
+	Context() context.Context
+	WithContext(ctx context.Context) inter.Request
+}
+
+// Convert the error of a done context to an error with a status
+func contextError(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case context.Canceled:
+		return ClientClosedRequestError
+	case context.DeadlineExceeded:
+		return RequestTimeoutError
+	}
+
+	return err
+}
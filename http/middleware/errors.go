@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/syslog/log_level"
+	net "net/http"
+)
+
+// The status code nginx uses when the client closes the connection
+const StatusClientClosedRequest = 499
+
+var ClientClosedRequestError = errors.New("client closed request").
+	Status(StatusClientClosedRequest).
+	Level(log_level.DEBUG)
+
+var RequestTimeoutError = errors.New("request timeout").
+	Status(net.StatusServiceUnavailable).
+	Level(log_level.WARNING)
+
+var InvalidSignatureError = errors.New("invalid signature").
+	Status(net.StatusForbidden).
+	Level(log_level.INFO)
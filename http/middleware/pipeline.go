@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
 	"github.com/confetti-framework/support/caller"
 	"sort"
 )
@@ -51,6 +52,12 @@ func (p Pipeline) Then(controller inter.Controller) inter.Response {
 	nextHolder := 0
 	pipes := p.Pipes
 
+	// Don't call the controller when nobody waits for the response anymore
+	destination := func(request inter.Request) inter.Response {
+		abortWhenDone(request)
+		return controller(request)
+	}
+
 	stabilizeOrder(holders)
 	pipes = reverse(pipes)
 
@@ -61,7 +68,7 @@ func (p Pipeline) Then(controller inter.Controller) inter.Response {
 		if i == 0 {
 			// Give the last pipe holder a destination controller
 			holder = func(request inter.Request) inter.Response {
-				response := pipe.Handle(request, controller)
+				response := pipe.Handle(request, destination)
 				// Ensure response has an application (needed when middleware returns a new response)
 				response.SetApp(request.App())
 				return response
@@ -79,12 +86,25 @@ func (p Pipeline) Then(controller inter.Controller) inter.Response {
 		holders = append(holders, holder)
 	}
 
-	holders = setDefaultHolder(controller, holders)
+	holders = setDefaultHolder(destination, holders)
 	nextHolder = getNextIndex(holders)
 
 	return holders[nextHolder](p.Passable)
 }
 
+// Stop handling the request when the context of the request is
+// done (e.g. when the client closed the connection).
+func abortWhenDone(request inter.Request) {
+	contextRequest, ok := request.(contextRequest)
+	if !ok {
+		return
+	}
+
+	if err := contextError(contextRequest.Context().Err()); err != nil {
+		panic(errors.Wrap(err, "stopped before %s", request.Path()))
+	}
+}
+
 // Ensure the pipe holders are stable sorted.
 func stabilizeOrder(holders []inter.PipeHolder) {
 	sort.SliceStable(holders, func(i, j int) bool {
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/syslog/log_level"
+	"time"
+)
+
+// Timeout responds with RequestTimeoutError when the next middleware and the
+// controller don't respond within the duration. The context of the request is
+// canceled, so the controller can stop its work (e.g. a database query).
+type Timeout struct {
+	Duration time.Duration
+	// The level to log the timeout with, WARNING when nil. Any level can be
+	// chosen, including EMERGENCY:
+	//
+	//	level := log_level.ERROR
+	//	middleware.Timeout{Duration: 5 * time.Second, Level: &level}
+	Level *log_level.Level
+}
+
+// The level of a timeout without a level
+const DefaultTimeoutLevel = log_level.WARNING
+
+func (t Timeout) Handle(request inter.Request, next inter.Next) inter.Response {
+	contextRequest, ok := request.(contextRequest)
+	if !ok {
+		return next(request)
+	}
+
+	ctx, cancel := context.WithTimeout(contextRequest.Context(), t.Duration)
+	defer cancel()
+	// The controller may still run after the timeout, so it gets its own copy
+	request = contextRequest.WithContext(ctx)
+
+	responses := make(chan inter.Response, 1)
+	panics := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				panics <- rec
+			}
+		}()
+		responses <- next(request)
+	}()
+
+	select {
+	case response := <-responses:
+		return response
+	case rec := <-panics:
+		panic(rec)
+	case <-ctx.Done():
+		// The controller may still be running, but the response is ignored
+		err := errors.Wrap(contextError(ctx.Err()), "no response within %s for %s", t.Duration, request.Path())
+		if errors.Is(err, RequestTimeoutError) {
+			panic(errors.WithLevel(err, t.level()))
+		}
+		panic(err)
+	}
+}
+
+func (t Timeout) level() log_level.Level {
+	if t.Level == nil {
+		return DefaultTimeoutLevel
+	}
+
+	return *t.Level
+}
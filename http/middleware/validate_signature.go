@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http/http_helper"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"strconv"
+	"time"
+)
+
+// ValidateSignature rejects requests of which the URL is not signed by
+// outcome.SignedUrlByName or outcome.TemporarySignedUrlByName, is
+// changed or has expired. The scheme and host are part of the signature, so
+// a URL signed for one (sub)domain is rejected on another.
+type ValidateSignature struct{}
+
+func (v ValidateSignature) Handle(request inter.Request, next inter.Next) inter.Response {
+	source := request.Source()
+	query := source.URL.Query()
+	target := *source.URL
+	target.Scheme = http_helper.Scheme(request.App(), request)
+	target.Host = http_helper.Host(request)
+
+	if !http_helper.HasValidSignature(outcome.AppKey(request.App()), &target) {
+		panic(InvalidSignatureError)
+	}
+
+	if expires := query.Get(http_helper.ExpiresParameter); expires != "" {
+		timestamp, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || time.Now().Unix() > timestamp {
+			panic(InvalidSignatureError.Wrap("the URL has expired"))
+		}
+	}
+
+	return next(request)
+}
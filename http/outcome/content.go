@@ -3,8 +3,9 @@ package outcome
 import (
 	"github.com/confetti-framework/contract/inter"
 	"github.com/confetti-framework/support"
-	"io/ioutil"
+	"net/http"
 	"os"
+	"path/filepath"
 )
 
 type ContentResponse struct {
@@ -29,18 +30,46 @@ func Download(filename string) inter.Response {
 }
 
 func DownloadE(filename string) (inter.Response, error) {
+	response, err := fileResponse(filename, "download")
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Filename(filepath.Base(filename)), nil
+}
+
+// Send the file to show it in the browser. Like a download, the
+// file supports Range and conditional requests.
+func File(filename string) inter.Response {
+	response, err := FileE(filename)
+	if err != nil {
+		panic(err)
+	}
+	return response
+}
+
+func FileE(filename string) (inter.Response, error) {
+	return fileResponse(filename, "serve")
+}
+
+func fileResponse(filename string, action string) (*StreamResponse, error) {
 	info, err := os.Stat(filename)
 	if os.IsNotExist(err) {
-		return nil, FileNotFoundError.Wrap("can't download file %s", filename)
-	}
-	if info.IsDir() {
-		return nil, CanNotDownloadDirectoryError.Wrap("can't download directory %s", filename)
+		return nil, FileNotFoundError.Wrap("can't %s file %s", action, filename)
 	}
-	content, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	response := Content(string(content)).Filename(info.Name())
+	if info.IsDir() {
+		return nil, CanNotDownloadDirectoryError.Wrap("can't %s directory %s", action, filename)
+	}
+	response := &StreamResponse{
+		Response: NewResponse(Options{}),
+		stream:   streamFile(filename),
+	}
+	response.serve = func(writer http.ResponseWriter, request *http.Request) {
+		response.serveFile(writer, request, filename)
+	}
 	mime, ok := support.MimeByExtension(info.Name())
 	if ok {
 		response.Header("Content-Type", mime)
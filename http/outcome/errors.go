@@ -7,3 +7,6 @@ import (
 
 var FileNotFoundError = errors.New("file not found").Status(net.StatusNotFound)
 var CanNotDownloadDirectoryError = FileNotFoundError
+var WebSocketRequiredError = errors.New("a WebSocket requires an HTTP connection to upgrade")
+var AppKeyNotFoundError = errors.New("no application key found in config.App.Key")
+var BaseUrlNotFoundError = errors.New("no base URL found in config.App.Url")
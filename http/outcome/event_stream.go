@@ -0,0 +1,124 @@
+package outcome
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Event is a Server-Sent Event. Data that is not a string or a
+// byte slice is encoded as JSON.
+type Event struct {
+	Id    string
+	Event string
+	Data  interface{}
+	Retry time.Duration
+}
+
+// EventSender sends events to the client.
+type EventSender struct {
+	ctx    context.Context
+	writer io.Writer
+}
+
+// Stream Server-Sent Events to the client. The callback sends events until it
+// returns. When the client disconnects, the context of the sender is canceled
+// and Send returns the error of the context.
+func EventStream(stream func(sender *EventSender) error) inter.Response {
+	response := &StreamResponse{
+		Response: NewResponse(Options{}),
+		stream: func(writer io.Writer) error {
+			return stream(&EventSender{ctx: context.Background(), writer: writer})
+		},
+	}
+	response.serve = func(writer http.ResponseWriter, request *http.Request) {
+		response.writeHeaders(writer)
+		writer.Header().Set("Content-Type", "text/event-stream")
+		writer.Header().Set("Cache-Control", "no-cache")
+		writer.Header().Set("Connection", "keep-alive")
+		// Prevent proxies (e.g. nginx) from buffering the events
+		writer.Header().Set("X-Accel-Buffering", "no")
+		writer.WriteHeader(response.GetStatus())
+
+		err := stream(&EventSender{ctx: request.Context(), writer: newFlushWriter(writer)})
+		if err != nil && request.Context().Err() == nil {
+			panic(http.ErrAbortHandler)
+		}
+	}
+
+	return response
+}
+
+// Context is canceled when the client disconnects.
+func (s *EventSender) Context() context.Context {
+	return s.ctx
+}
+
+// Send the event to the client.
+func (s *EventSender) Send(event Event) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := eventData(event.Data)
+	if err != nil {
+		return err
+	}
+
+	var message strings.Builder
+	if event.Id != "" {
+		message.WriteString("id: " + singleLine(event.Id) + "\n")
+	}
+	if event.Event != "" {
+		message.WriteString("event: " + singleLine(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		message.WriteString(fmt.Sprintf("retry: %d\n", event.Retry.Milliseconds()))
+	}
+	for _, line := range strings.Split(data, "\n") {
+		message.WriteString("data: " + line + "\n")
+	}
+	message.WriteString("\n")
+
+	_, err = io.WriteString(s.writer, message.String())
+	return err
+}
+
+// Send a comment. Clients ignore comments, so use it to keep the connection alive.
+func (s *EventSender) Comment(comment string) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(s.writer, ": "+singleLine(comment)+"\n\n")
+	return err
+}
+
+func eventData(data interface{}) (string, error) {
+	switch data := data.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(data), nil
+	case []byte:
+		return eventData(string(data))
+	}
+
+	result, err := json.Marshal(data)
+	if err != nil {
+		return "", errors.Wrap(err, "can't encode data of event")
+	}
+
+	return string(result), nil
+}
+
+// A new line would end the field, so we remove it
+func singleLine(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -0,0 +1,46 @@
+package outcome
+
+import (
+	"fmt"
+	"github.com/confetti-framework/errors"
+	"io"
+	"net/http"
+	"os"
+)
+
+// Stream the file. We open the file when the response is streamed, so
+// the file is not kept open when the response is never sent.
+func streamFile(filename string) func(writer io.Writer) error {
+	return func(writer io.Writer) error {
+		file, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(writer, file)
+		return err
+	}
+}
+
+// Serve the file with support for Range, If-Range, If-Modified-Since and
+// If-None-Match headers. Multiple ranges are sent as multipart/byteranges.
+func (s *StreamResponse) serveFile(writer http.ResponseWriter, request *http.Request, filename string) {
+	file, err := os.Open(filename)
+	if err != nil {
+		panic(FileNotFoundError.Wrap("can't download file %s", filename))
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		panic(errors.Wrap(err, "can't download file %s", filename))
+	}
+
+	s.writeHeaders(writer)
+	if writer.Header().Get("ETag") == "" {
+		writer.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()))
+	}
+
+	http.ServeContent(writer, request, info.Name(), info.ModTime(), file)
+}
@@ -0,0 +1,53 @@
+package outcome
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http/http_helper"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Receive the absolute URL to a named route with a signature. Use middleware
+// ValidateSignature to reject URLs that have been changed. The signature
+// is created with the key of the application (config.App.Key).
+func SignedUrlByName(app inter.App, name string, parameters ...Parameters) string {
+	return signUrl(app, name, parameters, nil)
+}
+
+// Receive the absolute URL to a named route with a signature that expires at
+// the expiration time.
+func TemporarySignedUrlByName(app inter.App, name string, expiration time.Time, parameters ...Parameters) string {
+	return signUrl(app, name, parameters, &expiration)
+}
+
+func signUrl(app inter.App, name string, parameters []Parameters, expiration *time.Time) string {
+	key := AppKey(app)
+	rawUrl := NewUrlGenerator(app).AbsoluteRoute(name, parameters...)
+
+	result, err := url.Parse(rawUrl)
+	if err != nil {
+		panic("URL cannot be signed because " + err.Error())
+	}
+
+	query := result.Query()
+	if expiration != nil {
+		query.Set(http_helper.ExpiresParameter, strconv.FormatInt(expiration.Unix(), 10))
+	}
+	result.RawQuery = query.Encode()
+	query.Set(http_helper.SignatureParameter, http_helper.Signature(key, result))
+	result.RawQuery = query.Encode()
+
+	return result.String()
+}
+
+// Receive the key of the application to sign URLs.
+func AppKey(app inter.App) string {
+	raw, err := app.MakeE("config.App.Key")
+	key, ok := raw.(string)
+	if err != nil || !ok || key == "" {
+		panic(AppKeyNotFoundError)
+	}
+
+	return key
+}
@@ -0,0 +1,141 @@
+package outcome
+
+import (
+	"bytes"
+	"github.com/confetti-framework/contract/inter"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Streamer is implemented by responses that write the body directly to the
+// client, instead of converting the content to a string first.
+type Streamer interface {
+	StreamTo(writer io.Writer) error
+}
+
+type StreamResponse struct {
+	*Response
+	stream func(writer io.Writer) error
+	// Serves the request instead of streaming (e.g. to support Range headers)
+	serve func(writer http.ResponseWriter, request *http.Request)
+}
+
+// Stream the reader to the client. If the reader is an io.Closer, it
+// is closed after streaming.
+func Stream(reader io.Reader) inter.Response {
+	return StreamFunc(func(writer io.Writer) error {
+		if closer, ok := reader.(io.Closer); ok {
+			defer closer.Close()
+		}
+		_, err := io.Copy(writer, reader)
+		return err
+	})
+}
+
+// Stream the body to the client by writing to the writer. The
+// writer is flushed after each write.
+func StreamFunc(stream func(writer io.Writer) error) inter.Response {
+	return &StreamResponse{
+		Response: NewResponse(Options{}),
+		stream:   stream,
+	}
+}
+
+func (s *StreamResponse) StreamTo(writer io.Writer) error {
+	return s.stream(writer)
+}
+
+// Write the headers, the status and the body to the client.
+func (s *StreamResponse) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if s.serve != nil {
+		s.serve(writer, request)
+		return
+	}
+
+	s.writeHeaders(writer)
+	writer.WriteHeader(s.GetStatus())
+
+	err := s.StreamTo(newFlushWriter(writer))
+	if err != nil {
+		// The status is already sent, we can only abort the response
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (s *StreamResponse) GetBody() string {
+	result, err := s.GetBodyE()
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// Read the complete stream into a string. Don't use this for large
+// bodies. A stream of a reader can only be read once.
+func (s *StreamResponse) GetBodyE() (string, error) {
+	var body bytes.Buffer
+	err := s.StreamTo(&body)
+
+	return body.String(), err
+}
+
+// The methods below return the stream response instead of the
+// embedded response, so the response keeps streaming when chained.
+
+func (s *StreamResponse) Status(status int) inter.Response {
+	s.Response.Status(status)
+	return s
+}
+
+func (s *StreamResponse) Header(key string, values ...string) inter.Response {
+	s.Response.Header(key, values...)
+	return s
+}
+
+func (s *StreamResponse) Headers(headers http.Header) inter.Response {
+	s.Response.Headers(headers)
+	return s
+}
+
+func (s *StreamResponse) Filename(filename string) inter.Response {
+	s.Response.Filename(filename)
+	return s
+}
+
+func (s *StreamResponse) ShowInBrowser() inter.Response {
+	s.Response.ShowInBrowser()
+	return s
+}
+
+func (s *StreamResponse) Cookie(cookies ...http.Cookie) inter.Response {
+	s.Response.Cookie(cookies...)
+	return s
+}
+
+func (s *StreamResponse) writeHeaders(writer http.ResponseWriter) {
+	for key, values := range s.GetHeaders() {
+		writer.Header().Add(key, strings.Join(values, "; "))
+	}
+}
+
+// flushWriter sends the written bytes to the client immediately.
+type flushWriter struct {
+	writer  http.ResponseWriter
+	flusher http.Flusher
+}
+
+func newFlushWriter(writer http.ResponseWriter) flushWriter {
+	flusher, _ := writer.(http.Flusher)
+	return flushWriter{writer: writer, flusher: flusher}
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.writer.Write(p)
+	if f.flusher != nil {
+		f.flusher.Flush()
+	}
+
+	return n, err
+}
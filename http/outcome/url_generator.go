@@ -1,28 +1,158 @@
 package outcome
 
 import (
-	"fmt"
 	"github.com/confetti-framework/contract/inter"
 	"github.com/confetti-framework/errors"
 	"github.com/confetti-framework/foundation/decorator/route_decorator"
 	"github.com/confetti-framework/foundation/http/http_helper"
 	"github.com/confetti-framework/foundation/http/method"
 	"github.com/confetti-framework/support"
+	"github.com/gorilla/mux"
+	"net/url"
+	"strings"
 )
 
 type Parameters map[string]interface{}
 
-// Receive the URL to a named route by app, name, uri parameters, query parameters
+// UrlGenerator generates the URLs to named routes and paths. The scheme and
+// host of absolute URLs are taken from the current request. Without a request
+// (e.g. in a command), the base URL of the application (config.App.Url) is used.
+// The generator is bound in the container as "url" for every request.
+type UrlGenerator struct {
+	app inter.App
+}
+
+func NewUrlGenerator(app inter.App) *UrlGenerator {
+	return &UrlGenerator{app: app}
+}
+
+// Receive the URL to a named route by app, name, uri parameters, query parameters.
+// The URL is relative, unless the route belongs to a domain.
 func UrlByName(app inter.App, name string, parameters ...Parameters) string {
-	var pairs []string
-	var result fmt.Stringer
+	return NewUrlGenerator(app).Route(name, parameters...)
+}
+
+// Receive the URL to a named route, or an error if the URL can't be generated.
+func UrlByNameE(app inter.App, name string, parameters ...Parameters) (string, error) {
+	return NewUrlGenerator(app).RouteE(name, parameters...)
+}
 
-	routes := app.Make("routes").(inter.RouteCollection)
-	route, err := RouteByName(routes, name)
+// Receive the URL to a named route by name, uri parameters, query parameters.
+// The URL is relative, unless the route belongs to a domain.
+func (g *UrlGenerator) Route(name string, parameters ...Parameters) string {
+	result, err := g.RouteE(name, parameters...)
 	if err != nil {
 		panic("URL cannot be generated because " + err.Error())
 	}
 
+	return result
+}
+
+func (g *UrlGenerator) RouteE(name string, parameters ...Parameters) (string, error) {
+	result, err := g.route(name, parameters...)
+	if err != nil {
+		return "", err
+	}
+
+	return result.String(), nil
+}
+
+// Receive the absolute URL to a named route.
+func (g *UrlGenerator) AbsoluteRoute(name string, parameters ...Parameters) string {
+	result, err := g.AbsoluteRouteE(name, parameters...)
+	if err != nil {
+		panic("URL cannot be generated because " + err.Error())
+	}
+
+	return result
+}
+
+func (g *UrlGenerator) AbsoluteRouteE(name string, parameters ...Parameters) (string, error) {
+	result, err := g.route(name, parameters...)
+	if err != nil {
+		return "", err
+	}
+
+	if result.Host == "" {
+		root, err := g.root()
+		if err != nil {
+			return "", err
+		}
+		result.Scheme, result.Host = root.Scheme, root.Host
+	}
+
+	return result.String(), nil
+}
+
+// Receive the absolute URL to a path with optional query parameters. An
+// absolute URL is returned as it is.
+func (g *UrlGenerator) To(path string, query ...Parameters) string {
+	result, err := g.ToE(path, query...)
+	if err != nil {
+		panic("URL cannot be generated because " + err.Error())
+	}
+
+	return result
+}
+
+func (g *UrlGenerator) ToE(path string, query ...Parameters) (string, error) {
+	result, err := url.Parse(path)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid path")
+	}
+
+	if len(query) > 0 {
+		values := result.Query()
+		for name, value := range query[0] {
+			values.Set(name, support.NewValue(value).String())
+		}
+		result.RawQuery = values.Encode()
+	}
+
+	if !result.IsAbs() {
+		root, err := g.root()
+		if err != nil {
+			return "", err
+		}
+		result.Scheme, result.Host = root.Scheme, root.Host
+		result.Path = "/" + strings.TrimPrefix(result.Path, "/")
+	}
+
+	return result.String(), nil
+}
+
+// Receive the scheme and host of the application (e.g. "https://example.com").
+func (g *UrlGenerator) Root() string {
+	root, err := g.RootE()
+	if err != nil {
+		panic(err)
+	}
+
+	return root
+}
+
+func (g *UrlGenerator) RootE() (string, error) {
+	root, err := g.root()
+	if err != nil {
+		return "", err
+	}
+
+	return root.String(), nil
+}
+
+func (g *UrlGenerator) route(name string, parameters ...Parameters) (*url.URL, error) {
+	var pairs []string
+
+	rawRoutes, err := g.app.MakeE("routes")
+	if err != nil {
+		return nil, err
+	}
+
+	route, err := RouteByName(rawRoutes.(inter.RouteCollection), name)
+	if err != nil {
+		return nil, err
+	}
+
 	UriParameters := Parameters{}
 	if len(parameters) > 0 {
 		UriParameters = parameters[0]
@@ -33,12 +163,12 @@ func UrlByName(app inter.App, name string, parameters ...Parameters) string {
 		QueryParameters = parameters[1]
 	}
 
-	// Remove Confetti custom placeholder
-	route = route_decorator.UriSuffixSlash{}.Revert(route)
-
-	muxRoute := http_helper.MuxFromRoute(route)
+	// Remove Confetti custom placeholder. The route is shared by all
+	// requests, so only a copy of the uri is changed.
+	uri := strings.ReplaceAll(route.Uri(), route_decorator.OptionalSlash, "")
+	muxRoute := http_helper.MuxFromRouteWithUri(route, uri)
 
-	for name, value := range UriParameters {
+	for name, value := range g.domainParameters(route, UriParameters) {
 		pairs = append(pairs, name, support.NewValue(value).String())
 	}
 
@@ -50,22 +180,103 @@ func UrlByName(app inter.App, name string, parameters ...Parameters) string {
 		muxRoute.Queries(name, "{"+name+"}")
 	}
 
-	muxRoute.Schemes("https")
+	result, err := muxRoute.URL(pairs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Host != "" {
+		result.Scheme = g.scheme()
+	}
+
+	return result, nil
+}
+
+// Parameters of the domain that are not given, are taken from the host of the
+// current request. So a URL to a route of the same account (e.g. the domain
+// "{account}.example.com") doesn't need the account.
+func (g *UrlGenerator) domainParameters(route inter.Route, parameters Parameters) Parameters {
+	if route.Domain() == "" {
+		return parameters
+	}
+
+	request, ok := g.request()
+	if !ok {
+		return parameters
+	}
+
+	var match mux.RouteMatch
+	source := request.Source()
+	if !new(mux.Route).Host(route.Domain()).Match(&source, &match) {
+		return parameters
+	}
+
+	result := Parameters{}
+	for name, value := range match.Vars {
+		result[name] = value
+	}
+	for name, value := range parameters {
+		result[name] = value
+	}
+
+	return result
+}
 
-	result, err = muxRoute.URL(pairs...)
+// The scheme of the current request, otherwise the scheme of the base URL.
+// Only when both are unknown, a secure connection is assumed.
+func (g *UrlGenerator) scheme() string {
+	if request, ok := g.request(); ok {
+		return http_helper.Scheme(g.app, request)
+	}
+	if base, err := g.baseUrl(); err == nil {
+		return base.Scheme
+	}
+
+	return "https"
+}
 
+func (g *UrlGenerator) root() (*url.URL, error) {
+	if request, ok := g.request(); ok {
+		return &url.URL{Scheme: http_helper.Scheme(g.app, request), Host: http_helper.Host(request)}, nil
+	}
+
+	base, err := g.baseUrl()
 	if err != nil {
-		panic("URL cannot be generated because " + err.Error())
+		return nil, err
+	}
+
+	return &url.URL{Scheme: base.Scheme, Host: base.Host}, nil
+}
+
+func (g *UrlGenerator) request() (inter.Request, bool) {
+	request, err := g.app.MakeE("request")
+	if err != nil {
+		return nil, false
 	}
 
-	return result.String()
+	result, ok := request.(inter.Request)
+	return result, ok
+}
+
+func (g *UrlGenerator) baseUrl() (*url.URL, error) {
+	raw, err := g.app.MakeE("config.App.Url")
+	if err != nil || raw == "" {
+		return nil, errors.WithStack(BaseUrlNotFoundError)
+	}
+
+	base, err := url.Parse(support.NewValue(raw).String())
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, BaseUrlNotFoundError.Wrap("invalid base URL %v", raw)
+	}
+
+	return base, nil
 }
 
 // Receive inter.Route by name
 func RouteByName(routes inter.RouteCollection, name string) (inter.Route, error) {
 	var matchedRoutes []inter.Route
 	for _, route := range routes.All() {
-		if route.Method() != method.Head && name == route.Name() {
+		if route.Method() != method.Head && name == route.Name() && !containsUrl(matchedRoutes, route) {
 			matchedRoutes = append(matchedRoutes, route)
 		}
 	}
@@ -80,3 +291,16 @@ func RouteByName(routes inter.RouteCollection, name string) (inter.Route, error)
 
 	return matchedRoutes[0], nil
 }
+
+// Routes with multiple methods (e.g. PUT and PATCH) generate the same URL
+func containsUrl(routes []inter.Route, route inter.Route) bool {
+	for _, existing := range routes {
+		if existing.Uri() == route.Uri() &&
+			existing.Domain() == route.Domain() &&
+			strings.Join(existing.RouteOptions().Prefixes(), "") == strings.Join(route.RouteOptions().Prefixes(), "") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+package outcome
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/gorilla/websocket"
+	"io"
+	"net/http"
+	"time"
+)
+
+// The message types of a WebSocket
+const (
+	TextMessage   = websocket.TextMessage
+	BinaryMessage = websocket.BinaryMessage
+)
+
+type WebSocketOptions struct {
+	// Determine if the origin of the request is allowed. By default, only
+	// requests of the same host are allowed.
+	CheckOrigin func(request *http.Request) bool
+	// The supported subprotocols in order of preference
+	Subprotocols []string
+}
+
+// WebSocketConnection is an upgraded connection with the client.
+type WebSocketConnection struct {
+	ctx  context.Context
+	conn *websocket.Conn
+}
+
+// Upgrade the connection to a WebSocket. The handler is called after all
+// middleware has handled the request. When the handler returns, the
+// connection is closed.
+func WebSocket(handler func(connection *WebSocketConnection) error, options ...WebSocketOptions) inter.Response {
+	var socketOptions WebSocketOptions
+	if len(options) > 0 {
+		socketOptions = options[0]
+	}
+
+	response := &StreamResponse{
+		Response: NewResponse(Options{}),
+		stream: func(writer io.Writer) error {
+			return WebSocketRequiredError
+		},
+	}
+	response.serve = func(writer http.ResponseWriter, request *http.Request) {
+		upgrader := websocket.Upgrader{
+			CheckOrigin:  socketOptions.CheckOrigin,
+			Subprotocols: socketOptions.Subprotocols,
+		}
+
+		// On failure, the upgrader has already responded with an HTTP error
+		conn, err := upgrader.Upgrade(writer, request, response.GetHeaders())
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		code, text := websocket.CloseNormalClosure, ""
+		if err := handler(&WebSocketConnection{ctx: request.Context(), conn: conn}); err != nil {
+			// Don't expose the error to the client
+			code, text = websocket.CloseInternalServerErr, http.StatusText(http.StatusInternalServerError)
+		}
+		message := websocket.FormatCloseMessage(code, text)
+		_ = conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(time.Second))
+	}
+
+	return response
+}
+
+// Context is canceled when the HTTP connection is closed.
+func (c *WebSocketConnection) Context() context.Context {
+	return c.ctx
+}
+
+// The subprotocol that is negotiated with the client
+func (c *WebSocketConnection) Subprotocol() string {
+	return c.conn.Subprotocol()
+}
+
+// Read the next message. The message type is TextMessage or BinaryMessage.
+func (c *WebSocketConnection) ReadMessage() (messageType int, data []byte, err error) {
+	return c.conn.ReadMessage()
+}
+
+// Read the next message as text.
+func (c *WebSocketConnection) ReadText() (string, error) {
+	_, data, err := c.conn.ReadMessage()
+	return string(data), err
+}
+
+// Read the next message and decode the JSON into the target.
+func (c *WebSocketConnection) ReadJson(target interface{}) error {
+	return c.conn.ReadJSON(target)
+}
+
+// Write a message. The message type is TextMessage or BinaryMessage.
+func (c *WebSocketConnection) WriteMessage(messageType int, data []byte) error {
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// Write a text message.
+func (c *WebSocketConnection) WriteText(text string) error {
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(text))
+}
+
+// Encode the value as JSON and write it as a text message.
+func (c *WebSocketConnection) WriteJson(value interface{}) error {
+	return c.conn.WriteJSON(value)
+}
+
+// Determine if the error is caused by the client that closed the connection
+func IsWebSocketClosed(err error) bool {
+	return websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived)
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"github.com/confetti-framework/contract/inter"
 	"github.com/confetti-framework/errors"
 	"github.com/confetti-framework/foundation/http/method"
@@ -118,6 +119,26 @@ func (r Request) Source() http.Request {
 	return r.source
 }
 
+// Context of the request. The context is canceled when the client closes
+// the connection, or when a middleware has set a context that is done.
+func (r Request) Context() context.Context {
+	return r.source.Context()
+}
+
+// Replace the context (e.g. to add values or a deadline). The
+// next middleware and the controller receive the new context.
+func (r *Request) SetContext(ctx context.Context) inter.Request {
+	r.source = *r.source.WithContext(ctx)
+	return r
+}
+
+// Receive a shallow copy of the request with the context. Unlike
+// SetContext, the request itself is not changed.
+func (r Request) WithContext(ctx context.Context) inter.Request {
+	r.source = *r.source.WithContext(ctx)
+	return &r
+}
+
 func (r Request) Method() string {
 	if r.source.Method == "" {
 		return method.Get
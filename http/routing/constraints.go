@@ -0,0 +1,88 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"regexp"
+	"strings"
+)
+
+// The patterns of the named constraints
+const (
+	PatternNumber       = `[0-9]+`
+	PatternAlpha        = `[a-zA-Z]+`
+	PatternAlphaNumeric = `[a-zA-Z0-9]+`
+	PatternUuid         = `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`
+)
+
+// Only match the parameters when they contain digits.
+func (c *RouteCollection) WhereNumber(parameters ...string) inter.RouteCollection {
+	return c.whereAll(parameters, PatternNumber)
+}
+
+// Only match the parameters when they contain letters.
+func (c *RouteCollection) WhereAlpha(parameters ...string) inter.RouteCollection {
+	return c.whereAll(parameters, PatternAlpha)
+}
+
+// Only match the parameters when they contain letters and digits.
+func (c *RouteCollection) WhereAlphaNumeric(parameters ...string) inter.RouteCollection {
+	return c.whereAll(parameters, PatternAlphaNumeric)
+}
+
+// Only match the parameters when they contain a UUID.
+func (c *RouteCollection) WhereUuid(parameters ...string) inter.RouteCollection {
+	return c.whereAll(parameters, PatternUuid)
+}
+
+// Only match the parameter when it is one of the values. Without values, an
+// empty segment would match, so at least one value is required.
+func (c *RouteCollection) WhereIn(parameter string, values ...string) inter.RouteCollection {
+	if len(values) == 0 {
+		panic(InvalidConstraintError.Wrap("no values given for parameter %s", parameter))
+	}
+
+	var quoted []string
+	for _, value := range values {
+		quoted = append(quoted, regexp.QuoteMeta(value))
+	}
+
+	return c.Where(parameter, "(?:"+strings.Join(quoted, "|")+")")
+}
+
+// Set a pattern for all routes with the parameter, including routes that are
+// added later. A constraint of the route itself (e.g. via Where) takes
+// precedence over the pattern. When the collection is merged into another
+// collection, the pattern only applies to the routes of this collection.
+func (c *RouteCollection) Pattern(parameter, regex string) inter.RouteCollection {
+	if c.patterns == nil {
+		c.patterns = map[string]string{}
+	}
+	c.patterns[parameter] = regex
+	c.recompile()
+
+	return c
+}
+
+func (c *RouteCollection) whereAll(parameters []string, regex string) inter.RouteCollection {
+	for _, parameter := range parameters {
+		c.Where(parameter, regex)
+	}
+
+	return c
+}
+
+// Apply the patterns to the routes without their own constraint
+func (c *RouteCollection) applyPatterns() {
+	for parameter, regex := range c.patterns {
+		for _, route := range c.routes {
+			if _, ok := route.Constraint()[parameter]; !ok && hasParameter(route, parameter) {
+				route.SetConstraint(parameter, regex)
+			}
+		}
+	}
+}
+
+func hasParameter(route inter.Route, parameter string) bool {
+	uri := route.Uri()
+	return strings.Contains(uri, "{"+parameter+"}") || strings.Contains(uri, "{"+parameter+"?}")
+}
@@ -0,0 +1,68 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/decorator/route_decorator"
+	"github.com/confetti-framework/support"
+	"sort"
+	"strings"
+)
+
+type RouteDescription struct {
+	Method string `json:"method"`
+	Domain string `json:"domain"`
+	// The uri including the prefixes
+	Uri         string            `json:"uri"`
+	Name        string            `json:"name"`
+	Constraints map[string]string `json:"constraints"`
+	Middleware  []string          `json:"middleware"`
+}
+
+// Describe the routes in the order in which they are matched.
+func Describe(routes inter.RouteCollection) []RouteDescription {
+	descriptions := []RouteDescription{}
+
+	for _, route := range routes.All() {
+		constraints := map[string]string{}
+		for parameter, regex := range route.Constraint() {
+			constraints[parameter] = regex
+		}
+
+		middlewares := []string{}
+		for _, middleware := range route.Middleware() {
+			middlewares = append(middlewares, support.Name(middleware))
+		}
+
+		descriptions = append(descriptions, RouteDescription{
+			Method:      route.Method(),
+			Domain:      route.Domain(),
+			Uri:         FullUri(route),
+			Name:        route.Name(),
+			Constraints: constraints,
+			Middleware:  middlewares,
+		})
+	}
+
+	return descriptions
+}
+
+// Get the uri of the route including the prefixes.
+func FullUri(route inter.Route) string {
+	uri := strings.TrimSuffix(pathTemplate(route), route_decorator.OptionalSlash)
+	if !strings.HasPrefix(uri, "/") {
+		return "/" + uri
+	}
+
+	return uri
+}
+
+// Get the constraints as "parameter: regex", sorted by parameter.
+func (d RouteDescription) ConstraintList() []string {
+	var result []string
+	for parameter, regex := range d.Constraints {
+		result = append(result, parameter+": "+regex)
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -10,3 +10,7 @@ var RouteError = errors.New("").Level(log_level.DEBUG)
 var MethodNotAllowedError = RouteError.Wrap("HTTP method not allowed").Status(net.StatusMethodNotAllowed)
 var RouteNotFoundError = RouteError.Wrap("no match was found for the specified URL").Status(net.StatusNotFound)
 var AppNotFoundError = RouteError.Wrap("inter.App not found in RouteCollection").Status(net.StatusInternalServerError).Level(log_level.CRITICAL)
+var ModelNotFoundError = RouteError.Wrap("no model found for the specified URL").Status(net.StatusNotFound)
+var ModelNotResolvedError = RouteError.Wrap("no model resolved").Status(net.StatusInternalServerError).Level(log_level.ERROR)
+var InvalidParameterError = RouteError.Wrap("invalid URL parameter").Status(net.StatusNotFound)
+var InvalidConstraintError = errors.New("invalid route constraint")
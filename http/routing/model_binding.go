@@ -0,0 +1,107 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/support"
+	"reflect"
+)
+
+// Resolver finds the model of the value of a URL parameter (e.g. a user by
+// id). Use the application of the request to receive a repository. Return nil
+// or ModelNotFoundError when the model does not exist.
+type Resolver func(request inter.Request, value string) (interface{}, error)
+
+// Finder is a repository that finds a model by the value of a parameter.
+type Finder interface {
+	Find(value string) (interface{}, error)
+}
+
+// Resolve the model with the Finder that is bound in the container by the
+// abstract (e.g. routes.Bind("user", routing.FindWith((*UserRepository)(nil)))).
+func FindWith(abstract interface{}) Resolver {
+	return func(request inter.Request, value string) (interface{}, error) {
+		concrete, err := request.MakeE(abstract)
+		if err != nil {
+			return nil, err
+		}
+
+		finder, ok := concrete.(Finder)
+		if !ok {
+			return nil, ModelNotResolvedError.Wrap("%s (%T) does not implement routing.Finder", support.Name(abstract), concrete)
+		}
+
+		return finder.Find(value)
+	}
+}
+
+// The models of a request are stored by parameter.
+const modelsAbstract = "route_models"
+
+// Receive the model of the parameter. The model is resolved after the
+// middlewares of the route, before the controller is called.
+func Model(request inter.Request, parameter string) interface{} {
+	model, err := ModelE(request, parameter)
+	if err != nil {
+		panic(err)
+	}
+
+	return model
+}
+
+func ModelE(request inter.Request, parameter string) (interface{}, error) {
+	models, err := request.App().MakeE(modelsAbstract)
+	if err != nil {
+		return nil, ModelNotResolvedError.Wrap("no model bound to parameter %s", parameter)
+	}
+
+	model, ok := models.(map[string]interface{})[parameter]
+	if !ok {
+		return nil, ModelNotResolvedError.Wrap("no model bound to parameter %s", parameter)
+	}
+
+	return model, nil
+}
+
+// Resolve the models of the parameters of the matched route. Optional
+// parameters without a value are skipped.
+func resolveModels(route inter.Route, request inter.Request) error {
+	bindingRoute, ok := route.(interface{ Bindings() map[string]Resolver })
+	if !ok || len(bindingRoute.Bindings()) == 0 {
+		return nil
+	}
+
+	models := map[string]interface{}{}
+	for parameter, resolver := range bindingRoute.Bindings() {
+		value, err := request.ParameterE(parameter)
+		if err != nil || value.String() == "" {
+			continue
+		}
+
+		model, err := resolver(request, value.String())
+		if err != nil {
+			return errors.Wrap(err, "resolve %s '%s'", parameter, value.String())
+		}
+		if isNil(model) {
+			return ModelNotFoundError.Wrap("no %s found for '%s'", parameter, value.String())
+		}
+		models[parameter] = model
+	}
+	request.App().Singleton(modelsAbstract, models)
+
+	return nil
+}
+
+func isNil(model interface{}) bool {
+	if model == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(model)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return value.IsNil()
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package routing
+
+import (
+	"encoding/hex"
+	"github.com/confetti-framework/contract/inter"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Uuid is a parsed UUID of a URL parameter
+type Uuid [16]byte
+
+var uuidRegex = regexp.MustCompile("^" + PatternUuid + "$")
+
+// Receive the parameter as int. When the parameter is not a number, the
+// panic results in a 404 response (because the URL does not exist).
+func ParameterInt(request inter.Request, key string) int {
+	result, err := ParameterIntE(request, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+func ParameterIntE(request inter.Request, key string) (int, error) {
+	value, err := request.ParameterE(key)
+	if err != nil {
+		return 0, InvalidParameterError.Wrap("parameter %s not found", key)
+	}
+
+	result, err := strconv.Atoi(value.String())
+	if err != nil {
+		return 0, InvalidParameterError.Wrap("parameter %s must be a number, '%s' given", key, value.String())
+	}
+
+	return result, nil
+}
+
+// Receive the parameter as UUID. When the parameter is not a UUID, the
+// panic results in a 404 response (because the URL does not exist).
+func ParameterUuid(request inter.Request, key string) Uuid {
+	result, err := ParameterUuidE(request, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+func ParameterUuidE(request inter.Request, key string) (Uuid, error) {
+	value, err := request.ParameterE(key)
+	if err != nil {
+		return Uuid{}, InvalidParameterError.Wrap("parameter %s not found", key)
+	}
+
+	result, err := ParseUuid(value.String())
+	if err != nil {
+		return Uuid{}, InvalidParameterError.Wrap("parameter %s must be a UUID, '%s' given", key, value.String())
+	}
+
+	return result, nil
+}
+
+// Parse a UUID in the format "123e4567-e89b-12d3-a456-426614174000".
+func ParseUuid(value string) (Uuid, error) {
+	var result Uuid
+	if !uuidRegex.MatchString(value) {
+		return result, InvalidParameterError.Wrap("invalid UUID '%s'", value)
+	}
+
+	_, err := hex.Decode(result[:], []byte(strings.ReplaceAll(value, "-", "")))
+
+	return result, err
+}
+
+// Get the UUID in lowercase with dashes
+func (u Uuid) String() string {
+	value := hex.EncodeToString(u[:])
+	return value[:8] + "-" + value[8:12] + "-" + value[12:16] + "-" + value[16:20] + "-" + value[20:]
+}
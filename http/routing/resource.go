@@ -0,0 +1,142 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http/method"
+	"strings"
+)
+
+// ResourceController contains the controllers of a resource. Routes
+// are only registered for the controllers that are present.
+type ResourceController struct {
+	Index   inter.Controller
+	Create  inter.Controller
+	Store   inter.Controller
+	Show    inter.Controller
+	Edit    inter.Controller
+	Update  inter.Controller
+	Destroy inter.Controller
+}
+
+type ResourceOptions struct {
+	// Only register these actions (e.g. "index", "show")
+	Only []string
+	// Register all actions except these
+	Except []string
+	// The name of the parameter, by default the singular of the resource
+	// (e.g. "photo" for "photos")
+	Parameter string
+}
+
+type resourceAction struct {
+	name       string
+	methods    []string
+	suffix     string
+	controller inter.Controller
+}
+
+// Register the routes of a resource:
+//
+//	GET       /photos               photos.index
+//	GET       /photos/create        photos.create
+//	POST      /photos               photos.store
+//	GET       /photos/{photo}       photos.show
+//	GET       /photos/{photo}/edit  photos.edit
+//	PUT/PATCH /photos/{photo}       photos.update
+//	DELETE    /photos/{photo}       photos.destroy
+//
+// Use a dot for nested resources: "photos.comments" results
+// in "/photos/{photo}/comments/{comment}".
+func Resource(name string, controller ResourceController, options ...ResourceOptions) *RouteCollection {
+	return resource(name, controller, resourceOptions(options), true)
+}
+
+// Register the routes of a resource without the create and edit
+// routes. These routes show HTML forms, which an API doesn't need.
+func ApiResource(name string, controller ResourceController, options ...ResourceOptions) *RouteCollection {
+	return resource(name, controller, resourceOptions(options), false)
+}
+
+func resource(name string, controller ResourceController, options ResourceOptions, forms bool) *RouteCollection {
+	uri, parameter := resourceUri(name)
+	if options.Parameter != "" {
+		parameter = options.Parameter
+	}
+	member := "/{" + parameter + "}"
+
+	actions := []resourceAction{
+		{"index", []string{method.Get, method.Head}, "", controller.Index},
+		{"create", []string{method.Get, method.Head}, "/create", controller.Create},
+		{"store", []string{method.Post}, "", controller.Store},
+		{"show", []string{method.Get, method.Head}, member, controller.Show},
+		{"edit", []string{method.Get, method.Head}, member + "/edit", controller.Edit},
+		{"update", []string{method.Put, method.Patch}, member, controller.Update},
+		{"destroy", []string{method.Delete}, member, controller.Destroy},
+	}
+
+	routes := NewRouteCollection()
+	for _, action := range actions {
+		if action.controller == nil || !options.includes(action.name) {
+			continue
+		}
+		if !forms && (action.name == "create" || action.name == "edit") {
+			continue
+		}
+		routes.Merge(createRoutes(action.methods, uri+action.suffix, action.controller).Name(name + "." + action.name))
+	}
+
+	return routes
+}
+
+// Get the uri and the parameter of the (nested) resource
+func resourceUri(name string) (string, string) {
+	var uri string
+	segments := strings.Split(name, ".")
+	for _, parent := range segments[:len(segments)-1] {
+		uri += "/" + parent + "/{" + singular(parent) + "}"
+	}
+	last := segments[len(segments)-1]
+
+	return uri + "/" + last, singular(last)
+}
+
+func (o ResourceOptions) includes(action string) bool {
+	if len(o.Only) > 0 && !contains(o.Only, action) {
+		return false
+	}
+
+	return !contains(o.Except, action)
+}
+
+func resourceOptions(options []ResourceOptions) ResourceOptions {
+	if len(options) > 0 {
+		return options[0]
+	}
+
+	return ResourceOptions{}
+}
+
+// A simple singular of an English resource name
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+
+	return name
+}
+
+func contains(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,6 +2,7 @@ package routing
 
 import (
 	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/decorator/route_decorator"
 	"github.com/confetti-framework/support"
 	"strings"
 )
@@ -13,6 +14,14 @@ type Route struct {
 	controller   inter.Controller
 	routeOptions RouteOptions
 	middlewares  []inter.HttpMiddleware
+	// The uri and domain before and after the decorators have changed them
+	plain     *routeUris
+	decorated routeUris
+}
+
+type routeUris struct {
+	uri    string
+	domain string
 }
 
 func NewRoute(url string, method string, controller inter.Controller) inter.Route {
@@ -47,8 +56,20 @@ func (r Route) RouteOptions() inter.RouteOptions {
 	return r.routeOptions
 }
 
+// Get the controller. With bindings, the models are resolved before the
+// controller is called. A model that is not found results in a 404.
 func (r Route) Controller() inter.Controller {
-	return r.controller
+	if len(r.routeOptions.bindings) == 0 {
+		return r.controller
+	}
+
+	return func(request inter.Request) inter.Response {
+		if err := resolveModels(&r, request); err != nil {
+			panic(err)
+		}
+
+		return r.controller(request)
+	}
 }
 
 func (r *Route) SetPrefix(prefix string) inter.Route {
@@ -112,6 +133,22 @@ func (r *Route) SetConstraint(parameter string, regex string) inter.Route {
 	return r
 }
 
+// Register a resolver for the model of the parameter.
+func (r *Route) SetBinding(parameter string, resolver Resolver) inter.Route {
+	if r.routeOptions.bindings == nil {
+		r.routeOptions.bindings = make(map[string]Resolver)
+	}
+
+	r.routeOptions.bindings[parameter] = resolver
+
+	return r
+}
+
+// Get the resolvers of the models by parameter
+func (r Route) Bindings() map[string]Resolver {
+	return r.routeOptions.bindings
+}
+
 func (r Route) Middleware() []inter.HttpMiddleware {
 	return r.middlewares
 }
@@ -139,6 +176,27 @@ func (r *Route) SetExcludeMiddleware(middlewares []inter.HttpMiddleware) inter.R
 	return r
 }
 
+// Restore the uri and domain as they were before the decorators changed them.
+// A uri or domain that has been set after decorating is kept.
+func (r *Route) undecorate() {
+	if r.plain == nil {
+		return
+	}
+	if r.uri == r.decorated.uri {
+		r.uri = r.plain.uri
+	}
+	if r.domain == r.decorated.domain {
+		r.domain = r.plain.domain
+	}
+}
+
+func (r *Route) decorate(decorators []inter.RouteDecorator) {
+	plain := routeUris{uri: r.uri, domain: r.domain}
+	route_decorator.Decorate(r, decorators)
+	r.plain = &plain
+	r.decorated = routeUris{uri: r.uri, domain: r.domain}
+}
+
 type RouteOptions struct {
 	prefixes           []string
 	destination        string
@@ -146,6 +204,7 @@ type RouteOptions struct {
 	constraints        map[string]string      // The regular expression requirements
 	name               string                 // Named routes allow the convenient generation of URLs or redirects
 	excludeMiddlewares []inter.HttpMiddleware // prevent the middleware from being applied to this route
+	bindings           map[string]Resolver    // Resolve the models of the parameters
 }
 
 func (r RouteOptions) Prefixes() []string {
@@ -4,9 +4,9 @@ import (
 	"github.com/confetti-framework/contract/inter"
 	"github.com/confetti-framework/errors"
 	"github.com/confetti-framework/foundation/decorator/route_decorator"
-	"github.com/confetti-framework/foundation/http/http_helper"
 	"github.com/confetti-framework/foundation/http/outcome"
-	"github.com/gorilla/mux"
+	"sync"
+	"sync/atomic"
 )
 
 type RouteCollection struct {
@@ -14,6 +14,12 @@ type RouteCollection struct {
 	routesMapRoutes inter.MapMethodRoutes
 	routes          []inter.Route
 	decorators      []inter.RouteDecorator
+	// The patterns by parameter name
+	patterns map[string]string
+	// The routes compiled at the first match (a *routeTree). Matching only
+	// loads the tree; the mutex is only used to compile the tree.
+	tree      atomic.Value
+	compiling sync.Mutex
 }
 
 func NewRouteCollection(routeCollections ...inter.RouteCollection) *RouteCollection {
@@ -33,11 +39,24 @@ func Group(routeCollections ...inter.RouteCollection) *RouteCollection {
 	return NewRouteCollection(routeCollections...)
 }
 
+// Decorate the routes. The routes are decorated again when the collection has
+// been changed, so the decorators start from the uri and domain as defined.
 func DecorateRoutes(routes *RouteCollection) {
 	for _, route := range routes.All() {
+		if route, ok := route.(*Route); ok {
+			route.undecorate()
+		}
+	}
+
+	routes.applyPatterns()
+
+	for _, route := range routes.All() {
+		if route, ok := route.(*Route); ok {
+			route.decorate(routes.decorators)
+			continue
+		}
 		route_decorator.Decorate(route, routes.decorators)
 	}
-	routes.decorators = []inter.RouteDecorator{}
 }
 
 func (c *RouteCollection) Push(route inter.Route) inter.RouteCollection {
@@ -49,6 +68,7 @@ func (c *RouteCollection) Push(route inter.Route) inter.RouteCollection {
 	c.routesMapRoutes[route.Method()] = append(routesByMethod, route)
 
 	c.routes = append(c.routes, route)
+	c.recompile()
 
 	return c
 }
@@ -58,28 +78,32 @@ func (c *RouteCollection) Merge(routeCollection inter.RouteCollection) inter.Rou
 		c.Push(route)
 	}
 
+	// The patterns of a group only apply to the routes of that group
+	if collection, ok := routeCollection.(*RouteCollection); ok {
+		collection.applyPatterns()
+	}
+
 	return c
 }
 
-func (c RouteCollection) All() []inter.Route {
+func (c *RouteCollection) All() []inter.Route {
 	return c.routes
 }
 
-func (c RouteCollection) Match(request inter.Request) inter.Route {
-	// Normally, the routes are already decorated. In that case, no decorators
-	// are present.
-	DecorateRoutes(&c)
+func (c *RouteCollection) Match(request inter.Request) inter.Route {
+	tree := c.compile()
 	routes, _ := request.App().MakeE("routes")
 	if routes == nil {
-		request.App().Singleton("routes", &c)
+		request.App().Singleton("routes", c)
 	}
 
-	routesWithUrl := c.getByMethod(request.Method())
+	source := request.Source()
+	candidates := tree.candidates(source.URL.Path)
 
 	// First, we will see if we can find a matching route for this current request
 	// method. If we can, great, we can just return it so that it can be called
 	// by the consumer. Otherwise we will check for routes with another verb.
-	route, found := c.matchAgainstRoutes(routesWithUrl, request)
+	route, found := c.matchAgainstRoutes(candidates, request)
 
 	if found {
 		return route
@@ -88,7 +112,7 @@ func (c RouteCollection) Match(request inter.Request) inter.Route {
 	// If no route was found we will now check if a matching route is specified by
 	// another HTTP verb. If it is we will need to throw a MethodNotAllowed and
 	// inform the user agent of which HTTP verb it should use for this route.
-	ok := c.hasAlternateMethod(request)
+	ok := c.hasAlternateMethod(candidates, request)
 
 	if ok {
 		return getErrorRoute(MethodNotAllowedError.Wrap("method %s is not supported for this url", request.Method()))
@@ -97,12 +121,39 @@ func (c RouteCollection) Match(request inter.Request) inter.Route {
 	return getErrorRoute(RouteNotFoundError)
 }
 
+// Decorate the routes and build the route tree. Normally, this happens once
+// at the first request. Changing the collection compiles the routes again.
+func (c *RouteCollection) compile() *routeTree {
+	if tree, _ := c.tree.Load().(*routeTree); tree != nil {
+		return tree
+	}
+
+	c.compiling.Lock()
+	defer c.compiling.Unlock()
+
+	tree, _ := c.tree.Load().(*routeTree)
+	if tree == nil {
+		DecorateRoutes(c)
+		tree = compileRoutes(c.routes)
+		c.tree.Store(tree)
+	}
+
+	return tree
+}
+
+// Compile the routes again at the next match
+func (c *RouteCollection) recompile() {
+	c.tree.Store((*routeTree)(nil))
+}
+
 // Set a group of global where patterns on the routes.
 func (c *RouteCollection) Where(parameter, regex string) inter.RouteCollection {
 	for _, route := range c.routes {
 		route.SetConstraint(parameter, regex)
 	}
 
+	c.recompile()
+
 	return c
 }
 
@@ -114,11 +165,28 @@ func (c *RouteCollection) WhereMulti(constraints map[string]string) inter.RouteC
 	return c
 }
 
+// Resolve the model of the parameter on all routes. See Resolver. The model is
+// resolved after the middlewares of the route (e.g. authentication), right before
+// the controller. Resolvers are registered by parameter name, not by the type of
+// the model: a controller has no typed parameters to derive the type from. Use
+// FindWith to resolve the model with a repository bound in the container.
+func (c *RouteCollection) Bind(parameter string, resolver Resolver) inter.RouteCollection {
+	for _, route := range c.routes {
+		if route, ok := route.(*Route); ok {
+			route.SetBinding(parameter, resolver)
+		}
+	}
+
+	return c
+}
+
 func (c *RouteCollection) Domain(domain string) inter.RouteCollection {
 	for _, route := range c.routes {
 		route.SetDomain(domain)
 	}
 
+	c.recompile()
+
 	return c
 }
 
@@ -127,6 +195,8 @@ func (c *RouteCollection) Prefix(prefix string) inter.RouteCollection {
 		route.SetPrefix(prefix)
 	}
 
+	c.recompile()
+
 	return c
 }
 
@@ -172,29 +242,30 @@ func (c *RouteCollection) setStatus(status int) *RouteCollection {
 	return c
 }
 
-func (c RouteCollection) getByMethod(method string) []inter.Route {
+func (c *RouteCollection) getByMethod(method string) []inter.Route {
 	return c.routesMapRoutes[method]
 }
 
-func (c *RouteCollection) matchAgainstRoutes(routes []inter.Route, request inter.Request) (inter.Route, bool) {
+func (c *RouteCollection) matchAgainstRoutes(routes []*compiledRoute, request inter.Request) (inter.Route, bool) {
 	source := request.Source()
-	var match mux.RouteMatch
 
 	for _, route := range routes {
-		muxRoute := http_helper.MuxFromRoute(route)
-		if err := muxRoute.GetError(); err != nil {
-			return getErrorRoute(err), true
+		if route.route.Method() != request.Method() {
+			continue
+		}
+		if route.err != nil {
+			return getErrorRoute(route.err), true
 		}
-		ok := muxRoute.Match(&source, &match)
 
+		vars, ok := route.match(&source)
 		if ok {
-			request.SetUrlValues(match.Vars)
+			request.SetUrlValues(vars)
 			if request.App() == nil {
 				return getErrorRoute(AppNotFoundError), true
 			}
-			request.App().Singleton("route", route)
+			request.App().Singleton("route", route.route)
 
-			return route, true
+			return route.route, true
 		}
 	}
 
@@ -202,12 +273,13 @@ func (c *RouteCollection) matchAgainstRoutes(routes []inter.Route, request inter
 	return nil, false
 }
 
-func (c RouteCollection) hasAlternateMethod(request inter.Request) bool {
-	for _, route := range c.routes {
-		var match mux.RouteMatch
-		source := request.Source()
-		ok := http_helper.MuxFromRoute(route).Match(&source, &match)
-		if ok {
+func (c *RouteCollection) hasAlternateMethod(routes []*compiledRoute, request inter.Request) bool {
+	source := request.Source()
+	for _, route := range routes {
+		if route.err != nil {
+			continue
+		}
+		if _, ok := route.match(&source); ok {
 			return true
 		}
 	}
@@ -0,0 +1,149 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/decorator/route_decorator"
+	"github.com/confetti-framework/foundation/http/http_helper"
+	"github.com/gorilla/mux"
+	net "net/http"
+	"sort"
+	"strings"
+)
+
+// routeTree finds the routes that can match a path, so the regular expressions
+// of the other routes are not executed. Routes are indexed by the static segments
+// at the beginning of their path. Static routes without a domain are found by
+// the complete path and don't need a regular expression at all.
+type routeTree struct {
+	root   *routeNode
+	static map[string][]*compiledRoute
+}
+
+type routeNode struct {
+	children map[string]*routeNode
+	routes   []*compiledRoute
+}
+
+type compiledRoute struct {
+	// The position in the collection, the first matching route wins
+	index int
+	route inter.Route
+	mux   *mux.Route
+	err   error
+	// A static route is found by the complete path
+	static     bool
+	path       string
+	allowSlash bool
+}
+
+func compileRoutes(routes []inter.Route) *routeTree {
+	tree := &routeTree{root: &routeNode{}, static: map[string][]*compiledRoute{}}
+
+	for index, route := range routes {
+		muxRoute := http_helper.MuxFromRoute(route)
+		compiled := &compiledRoute{index: index, route: route, mux: muxRoute, err: muxRoute.GetError()}
+
+		template := pathTemplate(route)
+		path := strings.TrimSuffix(template, route_decorator.OptionalSlash)
+		compiled.path = path
+		compiled.allowSlash = path != template
+
+		switch {
+		case compiled.err != nil:
+			// The error is returned when the route is reached
+			tree.root.routes = append(tree.root.routes, compiled)
+		case route.Domain() == "" && !strings.Contains(path, "{"):
+			compiled.static = true
+			tree.static[path] = append(tree.static[path], compiled)
+			if compiled.allowSlash {
+				tree.static[path+"/"] = append(tree.static[path+"/"], compiled)
+			}
+		default:
+			tree.root.insert(staticSegments(path), compiled)
+		}
+	}
+
+	return tree
+}
+
+// Get the routes that can match the path in the order of the collection.
+func (t *routeTree) candidates(path string) []*compiledRoute {
+	result := append([]*compiledRoute{}, t.static[path]...)
+
+	node := t.root
+	result = append(result, node.routes...)
+	for _, segment := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		node = node.children[segment]
+		if node == nil {
+			break
+		}
+		result = append(result, node.routes...)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].index < result[j].index
+	})
+
+	return result
+}
+
+func (n *routeNode) insert(segments []string, route *compiledRoute) {
+	if len(segments) == 0 {
+		n.routes = append(n.routes, route)
+		return
+	}
+
+	if n.children == nil {
+		n.children = map[string]*routeNode{}
+	}
+	child, ok := n.children[segments[0]]
+	if !ok {
+		child = &routeNode{}
+		n.children[segments[0]] = child
+	}
+
+	child.insert(segments[1:], route)
+}
+
+// Match the request and receive the parameters of the uri and the domain.
+func (c *compiledRoute) match(request *net.Request) (map[string]string, bool) {
+	if c.static {
+		vars := map[string]string{}
+		if c.allowSlash {
+			vars["allow_slash"] = strings.TrimPrefix(request.URL.Path, c.path)
+		}
+		return vars, true
+	}
+
+	var match mux.RouteMatch
+	if !c.mux.Match(request, &match) {
+		return nil, false
+	}
+
+	return match.Vars, true
+}
+
+// Build the path template the same way as mux: the trailing
+// slash of a prefix is replaced by the next part.
+func pathTemplate(route inter.Route) string {
+	template := ""
+	for _, prefix := range route.RouteOptions().Prefixes() {
+		template = strings.TrimRight(template, "/") + prefix
+	}
+
+	return strings.TrimRight(template, "/") + route.Uri()
+}
+
+// Get the complete segments before the first parameter. The template
+// "/users/{id}" and "/users/edit-{id}" both result in ["users"].
+func staticSegments(template string) []string {
+	literal := template
+	if i := strings.Index(template, "{"); i >= 0 {
+		literal = template[:i]
+	}
+
+	segments := strings.Split(strings.TrimPrefix(literal, "/"), "/")
+
+	// The last segment is not complete
+	return segments[:len(segments)-1]
+}
@@ -0,0 +1,163 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/support"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type StaticOptions struct {
+	// The value of the Cache-Control header (e.g. "public, max-age=31536000").
+	// No header is sent when empty.
+	CacheControl string
+	// The file that is served when a directory is requested, "index.html" by
+	// default. Use "-" to disable.
+	Index string
+}
+
+// Precompressed siblings in order of preference
+var encodings = []struct {
+	name      string
+	extension string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// Serve the files of a directory. The URI "/assets/css/app.css" with prefix
+// "/assets" serves the file "css/app.css" from the directory. Files outside
+// the directory can't be requested. When the client accepts it, a precompressed
+// sibling (e.g. "app.css.br" or "app.css.gz") is served instead.
+func Static(prefix string, dir string, options ...StaticOptions) inter.RouteCollection {
+	var staticOptions StaticOptions
+	if len(options) > 0 {
+		staticOptions = options[0]
+	}
+	if staticOptions.Index == "" {
+		staticOptions.Index = "index.html"
+	}
+
+	uri := strings.TrimSuffix(prefix, "/") + "/{static_path}"
+
+	return Get(uri, staticController(dir, staticOptions)).Where("static_path", ".*")
+}
+
+func staticController(dir string, options StaticOptions) inter.Controller {
+	return func(request inter.Request) inter.Response {
+		filename, ok := staticFile(dir, request.Parameter("static_path").String(), options.Index)
+		if !ok {
+			panic(RouteNotFoundError.Wrap("no static file found for %s", request.Path()))
+		}
+
+		response := staticResponse(filename, request.Header("Accept-Encoding"))
+		if options.CacheControl != "" {
+			response.Header("Cache-Control", options.CacheControl)
+		}
+
+		return response
+	}
+}
+
+// Get the file on disk. The path is cleaned as an absolute path first,
+// so "../" can never leave the directory.
+func staticFile(dir string, uriPath string, index string) (string, bool) {
+	filename := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+uriPath)))
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return "", false
+	}
+	if !info.IsDir() {
+		return filename, true
+	}
+	if index == "-" {
+		return "", false
+	}
+
+	filename = filepath.Join(filename, index)
+	info, err = os.Stat(filename)
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+
+	return filename, true
+}
+
+// Serve the first precompressed sibling the client accepts. As soon as a
+// sibling exists, the response depends on Accept-Encoding, also when the
+// file itself is served. Otherwise a shared cache could serve the file to
+// clients that accept the compressed sibling (and the other way around).
+func staticResponse(filename string, acceptEncoding string) inter.Response {
+	var response inter.Response
+	compressed := false
+	for _, encoding := range encodings {
+		info, err := os.Stat(filename + encoding.extension)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		compressed = true
+		if response != nil || !accepts(acceptEncoding, encoding.name) {
+			continue
+		}
+
+		response = outcome.File(filename + encoding.extension)
+		response.Header("Content-Encoding", encoding.name)
+		if mime, ok := support.MimeByExtension(filepath.Base(filename)); ok {
+			response.Header("Content-Type", mime)
+		} else {
+			response.Header("Content-Type", "application/octet-stream")
+		}
+	}
+
+	if response == nil {
+		response = outcome.File(filename)
+	}
+	if compressed {
+		response.Header("Vary", "Accept-Encoding")
+	}
+
+	return response
+}
+
+// Determine if the Accept-Encoding header accepts the encoding. The encoding
+// itself takes precedence over "*". An encoding with a quality of 0 (e.g.
+// "br;q=0" or "br;q=0.0") is not acceptable.
+func accepts(acceptEncoding string, encoding string) bool {
+	wildcard := false
+	for _, accepted := range strings.Split(acceptEncoding, ",") {
+		name, quality := acceptedEncoding(accepted)
+		if strings.EqualFold(name, encoding) {
+			return quality > 0
+		}
+		if name == "*" {
+			wildcard = quality > 0
+		}
+	}
+
+	return wildcard
+}
+
+// Get the name and the quality of an encoding in the Accept-Encoding header.
+// The quality is 1 by default, an invalid quality is not acceptable.
+func acceptedEncoding(accepted string) (string, float64) {
+	parts := strings.Split(accepted, ";")
+	name := strings.TrimSpace(parts[0])
+	for _, parameter := range parts[1:] {
+		pair := strings.SplitN(parameter, "=", 2)
+		if len(pair) != 2 || !strings.EqualFold(strings.TrimSpace(pair[0]), "q") {
+			continue
+		}
+		quality, err := strconv.ParseFloat(strings.TrimSpace(pair[1]), 64)
+		if err != nil {
+			return name, 0
+		}
+		return name, quality
+	}
+
+	return name, 1
+}
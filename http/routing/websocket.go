@@ -0,0 +1,22 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/outcome"
+)
+
+// WebSocketHandler communicates with the client over the upgraded connection.
+// The request is the request that is upgraded.
+type WebSocketHandler func(connection *outcome.WebSocketConnection, request inter.Request) error
+
+// Register a new GET route that upgrades the connection to a WebSocket. The
+// middleware of the route runs before the upgrade, so a middleware can still
+// reject the request (e.g. for authentication or rate limiting).
+func WebSocket(uri string, handler WebSocketHandler, options ...outcome.WebSocketOptions) *RouteCollection {
+	return createRoute(method.Get, uri, func(request inter.Request) inter.Response {
+		return outcome.WebSocket(func(connection *outcome.WebSocketConnection) error {
+			return handler(connection, request)
+		}, options...)
+	})
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/config"
+	network "net"
+	net "net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// ServerConfig is decoded from the configuration with key "Http".
+type ServerConfig struct {
+	Addr string `default:":8080"`
+	// Without a read or write timeout, streams (e.g. Server-Sent Events)
+	// can stay open. Use the Timeout middleware to limit the controllers.
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration `default:"10s"`
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration `default:"120s"`
+	MaxHeaderBytes    int           `default:"1048576"`
+	// Serve HTTPS when both files are given
+	TlsCert string
+	TlsKey  string
+	// The time in-flight requests get to finish on shutdown
+	ShutdownTimeout time.Duration `default:"10s"`
+	// The proxies (IP addresses or CIDR ranges) of which the X-Forwarded-Proto
+	// header is trusted, see http_helper.Scheme
+	TrustedProxies []string
+}
+
+// Server serves the HTTP kernel of the application. Each request
+// receives a new application based on the container of the boot application.
+type Server struct {
+	App    inter.App
+	Config ServerConfig
+	// The signals to shut down on, SIGINT and SIGTERM by default
+	Signals []os.Signal
+}
+
+// Create a server with the configuration of the application.
+func NewServer(app inter.App) (Server, error) {
+	source, err := app.MakeE("config.Http")
+	if err != nil {
+		// Without configuration, the defaults are used
+		source = map[string]interface{}{}
+	}
+
+	server := Server{App: app}
+	if err := config.Decode(source, &server.Config); err != nil {
+		return server, errors.Wrap(err, "invalid HTTP server configuration")
+	}
+
+	return server, nil
+}
+
+func (s Server) ServeHTTP(response net.ResponseWriter, request *net.Request) {
+	app := foundation.NewApp()
+	app.SetContainer(foundation.NewContainerByBoot(*s.App.Container()))
+
+	HandleHttpKernel(app, response, request)
+}
+
+// Listen on the configured address and serve the requests until a
+// signal is received.
+func (s Server) ListenAndServe() error {
+	listener, err := network.Listen("tcp", s.Config.Addr)
+	if err != nil {
+		return errors.Wrap(err, "can't listen on %s", s.Config.Addr)
+	}
+
+	return s.Serve(listener)
+}
+
+// Serve the requests of the listener until a signal is received. Then the
+// server stops accepting connections, in-flight requests get the shutdown
+// timeout to finish and the application terminates.
+func (s Server) Serve(listener network.Listener) error {
+	if booter, ok := s.App.(foundation.Booter); ok {
+		booter.Booted()
+	}
+
+	server := s.httpServer()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, s.signals()...)
+	defer signal.Stop(signals)
+
+	stopped := make(chan error, 1)
+	go func() {
+		if s.Config.TlsCert != "" && s.Config.TlsKey != "" {
+			stopped <- server.ServeTLS(listener, s.Config.TlsCert, s.Config.TlsKey)
+		} else {
+			stopped <- server.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-stopped:
+		return errors.Wrap(err, "server stopped")
+	case <-signals:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.Config.ShutdownTimeout)
+	defer cancel()
+
+	var result error
+	if err := server.Shutdown(ctx); err != nil {
+		result = errors.Wrap(err, "in-flight requests are not finished")
+	}
+	if app, ok := s.App.(foundation.Terminator); ok {
+		if err := app.Terminate(ctx); err != nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+func (s Server) httpServer() *net.Server {
+	return &net.Server{
+		Addr:              s.Config.Addr,
+		Handler:           s,
+		ReadTimeout:       s.Config.ReadTimeout,
+		ReadHeaderTimeout: s.Config.ReadHeaderTimeout,
+		WriteTimeout:      s.Config.WriteTimeout,
+		IdleTimeout:       s.Config.IdleTimeout,
+		MaxHeaderBytes:    s.Config.MaxHeaderBytes,
+	}
+}
+
+func (s Server) signals() []os.Signal {
+	if len(s.Signals) == 0 {
+		return []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	return s.Signals
+}
@@ -0,0 +1,252 @@
+package foundation
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/support"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Lifecycle holds the hooks that are executed when the application
+// has booted and when the application terminates. The lifecycle is
+// stored in the container created at boot time, so the hooks are
+// shared by all requests.
+type Lifecycle struct {
+	mutex      sync.Mutex
+	booted     bool
+	terminated bool
+	// Closed when the application has terminated
+	finished    chan struct{}
+	onBooted    []func(app inter.App)
+	onTerminate []func(ctx context.Context, app inter.App) error
+	onFinished  []func(app inter.App)
+
+	// The requests that are currently handled
+	requests sync.WaitGroup
+}
+
+// Booter is implemented by applications with booted hooks. The kernels call
+// Booted before they handle the first request or command.
+type Booter interface {
+	Booted()
+}
+
+// Terminator is implemented by applications that can terminate gracefully.
+type Terminator interface {
+	Terminate(ctx context.Context) error
+}
+
+// Prevents multiple lifecycles from being created for the same container.
+var lifecycleMutex sync.Mutex
+
+// Register a hook that is executed when the application has booted. If
+// the application has already booted, the hook is executed immediately.
+func (a *Application) OnBooted(hook func(app inter.App)) {
+	lifecycle := a.lifecycle()
+
+	lifecycle.mutex.Lock()
+	booted := lifecycle.booted
+	if !booted {
+		lifecycle.onBooted = append(lifecycle.onBooted, hook)
+	}
+	lifecycle.mutex.Unlock()
+
+	if booted {
+		hook(a)
+	}
+}
+
+// Register a hook that is executed when the application terminates. Use it
+// to close connections and flush writers. The context contains the deadline.
+func (a *Application) OnTerminating(hook func(ctx context.Context, app inter.App) error) {
+	lifecycle := a.lifecycle()
+
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+
+	lifecycle.onTerminate = append(lifecycle.onTerminate, hook)
+}
+
+// Register a hook that is executed after all terminating hooks are executed.
+func (a *Application) OnTerminated(hook func(app inter.App)) {
+	lifecycle := a.lifecycle()
+
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+
+	lifecycle.onFinished = append(lifecycle.onFinished, hook)
+}
+
+// Execute the booted hooks. The hooks are executed only once.
+func (a *Application) Booted() {
+	lifecycle := a.lifecycle()
+
+	lifecycle.mutex.Lock()
+	if lifecycle.booted {
+		lifecycle.mutex.Unlock()
+		return
+	}
+	lifecycle.booted = true
+	hooks := lifecycle.onBooted
+	lifecycle.mutex.Unlock()
+
+	for _, hook := range hooks {
+		hook(a)
+	}
+}
+
+// Mark the start of a request. The application waits for the
+// request to finish before terminating. Call the returned function
+// when the request is finished. Once the application terminates,
+// new requests are refused with ApplicationTerminatingError.
+func (a *Application) StartRequest() (func(), error) {
+	lifecycle := a.lifecycle()
+
+	// Add may not run at the same time as the Wait of Terminate
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+	if lifecycle.terminated {
+		return nil, errors.WithStack(ApplicationTerminatingError)
+	}
+	lifecycle.requests.Add(1)
+
+	return lifecycle.requests.Done, nil
+}
+
+// Terminate the application. First, we wait for the requests to finish.
+// Then the terminating hooks and the terminated hooks are executed in
+// reverse registration order. Hooks that have not started before the
+// deadline of the context are skipped. The application terminates only once,
+// other calls wait until the application has terminated.
+func (a *Application) Terminate(ctx context.Context) error {
+	lifecycle := a.lifecycle()
+
+	lifecycle.mutex.Lock()
+	if lifecycle.terminated {
+		finished := lifecycle.finished
+		lifecycle.mutex.Unlock()
+		select {
+		case <-finished:
+			return nil
+		case <-ctx.Done():
+			return TerminateTimeoutError.Wrap("the application is still terminating")
+		}
+	}
+	lifecycle.terminated = true
+	lifecycle.finished = make(chan struct{})
+	defer close(lifecycle.finished)
+	onTerminate := lifecycle.onTerminate
+	onFinished := lifecycle.onFinished
+	lifecycle.mutex.Unlock()
+
+	var result error
+	if err := waitForRequests(ctx, &lifecycle.requests); err != nil {
+		result = err
+	}
+
+	for i := len(onTerminate) - 1; i >= 0; i-- {
+		if ctx.Err() != nil {
+			result = combineErrors(result, TerminateTimeoutError.Wrap("%d terminating hooks are skipped", i+1))
+			break
+		}
+		if err := onTerminate[i](ctx, a); err != nil {
+			result = combineErrors(result, err)
+		}
+	}
+
+	for i := len(onFinished) - 1; i >= 0; i-- {
+		onFinished[i](a)
+	}
+
+	return result
+}
+
+// Terminate the application when a signal is received. Without signals, the
+// application terminates on SIGINT and SIGTERM. The callback receives the result
+// of Terminate. Call the returned function to stop listening for signals.
+func TerminateOnSignal(
+	app Terminator,
+	timeout time.Duration,
+	terminated func(err error),
+	signals ...os.Signal,
+) func() {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	received := make(chan os.Signal, 1)
+	stop := make(chan struct{})
+	signal.Notify(received, signals...)
+
+	go func() {
+		select {
+		case <-received:
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			terminated(app.Terminate(ctx))
+		case <-stop:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(stop)
+		})
+	}
+}
+
+// Get the lifecycle from the container created at boot time.
+func (a *Application) lifecycle() *Lifecycle {
+	lifecycleMutex.Lock()
+	defer lifecycleMutex.Unlock()
+
+	root := rootContainer(*a.container)
+	if root.Bound(support.Name((*Lifecycle)(nil))) {
+		return root.Make((*Lifecycle)(nil)).(*Lifecycle)
+	}
+
+	lifecycle := &Lifecycle{}
+	root.Bind((*Lifecycle)(nil), lifecycle)
+
+	return lifecycle
+}
+
+func rootContainer(container inter.Container) inter.Container {
+	for {
+		foundationContainer, ok := container.(*Container)
+		if !ok || foundationContainer.bootContainer == nil {
+			return container
+		}
+		container = foundationContainer.bootContainer
+	}
+}
+
+func waitForRequests(ctx context.Context, requests *sync.WaitGroup) error {
+	finished := make(chan struct{})
+	go func() {
+		requests.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return TerminateTimeoutError.Wrap("requests are still being handled")
+	}
+}
+
+func combineErrors(previous error, err error) error {
+	if previous == nil {
+		return err
+	}
+
+	return errors.Wrap(err, previous.Error())
+}
@@ -91,7 +91,13 @@ func (r Syslog) LogWith(severity log_level.Level, message string, rawContext int
 
 	structuredData["level"] = syslog.SDElement{"severity": syslog.KeyBySeverity(severity)}
 
-	r.init().Log(
+	logger, file := r.init()
+	if file != nil {
+		// The file is opened for each log, so we close it when the log is written
+		defer file.Close()
+	}
+
+	logger.Log(
 		severity,
 		r.group,
 		structuredData,
@@ -100,16 +106,18 @@ func (r Syslog) LogWith(severity log_level.Level, message string, rawContext int
 	)
 }
 
-func (r Syslog) init() syslog.Logger {
+func (r Syslog) init() (syslog.Logger, *os.File) {
 	hostname, _ := os.Hostname()
+	var file *os.File
 	if r.Writer == nil {
-		r.Writer = fileWriter(r)
+		file = fileWriter(r)
+		r.Writer = file
 	}
 
 	appName := r.app.Make("config.App.Name").(string)
 	procid := strconv.Itoa(os.Getpid())
 
-	return syslog.NewLogger(r.Writer, r.Facility, hostname, appName, procid)
+	return syslog.NewLogger(r.Writer, r.Facility, hostname, appName, procid), file
 }
 
 func fileWriter(r Syslog) *os.File {
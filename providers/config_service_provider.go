@@ -2,14 +2,44 @@ package providers
 
 import (
 	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/config"
+	"github.com/spf13/cast"
 )
 
 type ConfigServiceProvider struct {
 	Index map[string]interface{}
+	// Loader merges files and environment variables into the index. The
+	// index is used as defaults. Without loader, the index is used as is.
+	// When the cache of the loader exists, only the cache is merged. A cache
+	// of another environment is ignored with a warning (see Loader.Warn).
+	Loader *config.Loader
 }
 
 func (c ConfigServiceProvider) Register(container inter.Container) inter.Container {
-	container.Bind("config", c.Index)
+	index := c.Index
+	if c.Loader != nil {
+		loader := *c.Loader
+		if loader.Defaults == nil {
+			loader.Defaults = c.Index
+		}
+		container.Bind(config.Loader{}, loader)
+		index = load(container, loader)
+	}
+
+	container.Bind("config", index)
+	container.Bind(config.Repository{}, config.NewRepository(index))
 
 	return container
 }
+
+// Load the configuration for the environment of the application. The
+// application can't boot with an invalid configuration, so we panic.
+func load(container inter.Container, loader config.Loader) map[string]interface{} {
+	environment, _ := container.MakeE("env")
+	index, err := loader.Load(cast.ToString(environment))
+	if err != nil {
+		panic(err)
+	}
+
+	return index
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	configLoader "github.com/confetti-framework/foundation/config"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type loggingConfig struct {
+	Default  string        `config:"default_channel" required:"true"`
+	Retries  int           `default:"3" min:"1" max:"10"`
+	Timeout  time.Duration `default:"5s" max:"1m"`
+	Channels []string      `min:"1"`
+	Syslog   syslogConfig
+}
+
+type syslogConfig struct {
+	Path  string `required:"true"`
+	Level string `default:"debug"`
+}
+
+func Test_decode_map_with_defaults(t *testing.T) {
+	var result loggingConfig
+	err := configLoader.Decode(map[string]interface{}{
+		"default_channel": "stack",
+		"channels":        []interface{}{"stack", "single"},
+		"Syslog":          map[string]interface{}{"path": "/var/log/app.log"},
+		"unknown":         true,
+	}, &result)
+
+	require.NoError(t, err)
+	require.Equal(t, loggingConfig{
+		Default:  "stack",
+		Retries:  3,
+		Timeout:  5 * time.Second,
+		Channels: []string{"stack", "single"},
+		Syslog:   syslogConfig{Path: "/var/log/app.log", Level: "debug"},
+	}, result)
+}
+
+func Test_decode_converts_values(t *testing.T) {
+	var result loggingConfig
+	err := configLoader.Decode(map[string]interface{}{
+		"default_channel": "stack",
+		"Retries":         "5",
+		"Timeout":         "30s",
+		"Channels":        "stack,single",
+		"Syslog":          map[string]interface{}{"Path": "/tmp/app.log"},
+	}, &result)
+
+	require.NoError(t, err)
+	require.Equal(t, 5, result.Retries)
+	require.Equal(t, 30*time.Second, result.Timeout)
+	require.Equal(t, []string{"stack", "single"}, result.Channels)
+}
+
+func Test_decode_struct_keeps_explicit_zero_values(t *testing.T) {
+	type appConfig struct {
+		Debug   bool `default:"true"`
+		Retries int  `default:"3"`
+		Name    string
+	}
+	source := struct {
+		Debug   bool
+		Retries int
+	}{Debug: false, Retries: 0}
+
+	var result appConfig
+	err := configLoader.Decode(source, &result)
+
+	require.NoError(t, err)
+	require.Equal(t, appConfig{Debug: false, Retries: 0}, result)
+}
+
+func Test_decode_map_keeps_explicit_zero_values(t *testing.T) {
+	type appConfig struct {
+		Debug   bool `default:"true"`
+		Retries int  `default:"3"`
+	}
+
+	var result appConfig
+	err := configLoader.Decode(map[string]interface{}{"Debug": false}, &result)
+
+	require.NoError(t, err)
+	require.Equal(t, appConfig{Debug: false, Retries: 3}, result)
+}
+
+func Test_decode_struct_validates_zero_values(t *testing.T) {
+	source := struct {
+		Default_channel string
+		Retries         int
+		Channels        []string
+		Syslog          syslogConfig
+	}{Default_channel: "stack", Channels: []string{"stack"}, Syslog: syslogConfig{Path: "/tmp/app.log"}}
+
+	var result loggingConfig
+	err := configLoader.Decode(source, &result)
+
+	require.EqualError(t, err, "key 'Retries': must be at least 1: invalid configuration")
+}
+
+func Test_decode_reports_all_problems(t *testing.T) {
+	var result loggingConfig
+	err := configLoader.Decode(map[string]interface{}{
+		"Retries":  20,
+		"Timeout":  "2m",
+		"Channels": []string{"stack"},
+	}, &result)
+
+	require.True(t, errors.Is(err, configLoader.InvalidConfigurationError))
+	require.Contains(t, err.Error(), "key 'default_channel' is required")
+	require.Contains(t, err.Error(), "key 'Retries': must be at most 10")
+	require.Contains(t, err.Error(), "key 'Timeout': must be at most 1m")
+	require.Contains(t, err.Error(), "key 'Syslog.Path' is required")
+	require.Equal(t, loggingConfig{}, result)
+}
+
+func Test_decode_invalid_value(t *testing.T) {
+	var result syslogConfig
+	err := configLoader.Decode(map[string]interface{}{"Path": []string{"a"}, "Level": 1}, &result)
+
+	require.EqualError(t, err, `key 'Path': can not convert []string{"a"} to string; key 'Path' is required: invalid configuration`)
+}
+
+func Test_decode_into_non_pointer(t *testing.T) {
+	err := configLoader.Decode(map[string]interface{}{}, syslogConfig{})
+
+	require.True(t, errors.Is(err, configLoader.InvalidTargetError))
+}
+
+func Test_config_into(t *testing.T) {
+	app := foundation.NewTestApp(func(container inter.Container) inter.Container {
+		container.Bind("config", map[string]interface{}{
+			"Logging": map[string]interface{}{
+				"default_channel": "stack",
+				"Channels":        []string{"stack"},
+				"Syslog":          map[string]interface{}{"Path": "/tmp/app.log"},
+			},
+		})
+		return container
+	}).(*foundation.Application)
+
+	var result loggingConfig
+	err := app.ConfigInto("Logging", &result)
+
+	require.NoError(t, err)
+	require.Equal(t, "stack", result.Default)
+	require.Equal(t, "/tmp/app.log", result.Syslog.Path)
+	require.Equal(t, "debug", result.Syslog.Level)
+}
+
+func Test_config_into_with_not_existing_key(t *testing.T) {
+	app := foundation.NewTestApp(func(container inter.Container) inter.Container {
+		container.Bind("config", map[string]interface{}{})
+		return container
+	}).(*foundation.Application)
+
+	var result loggingConfig
+	err := app.ConfigInto("Logging", &result)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bind config 'Logging'")
+}
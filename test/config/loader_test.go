@@ -0,0 +1,241 @@
+package config
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	configLoader "github.com/confetti-framework/foundation/config"
+	"github.com/confetti-framework/foundation/providers"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type appConfig struct {
+	Name     string
+	Debug    bool
+	Timeout  time.Duration
+	Port     int
+	Hosts    []string
+	Database databaseConfig
+}
+
+type databaseConfig struct {
+	Host     string
+	Password string
+}
+
+var defaults = map[string]interface{}{
+	"App": appConfig{Name: "Confetti", Port: 80},
+}
+
+func Test_load_defaults(t *testing.T) {
+	index, err := configLoader.Loader{Defaults: defaults, Environ: environ()}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, defaults, index)
+}
+
+func Test_load_does_not_change_defaults(t *testing.T) {
+	_, err := configLoader.Loader{Defaults: defaults, Environ: environ("APP_APP__NAME=Other")}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, "Confetti", defaults["App"].(appConfig).Name)
+}
+
+func Test_load_json_file(t *testing.T) {
+	path := configDir(t, map[string]string{"config.json": `{"App": {"Debug": true, "Port": 8080}}`})
+
+	index, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ()}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, appConfig{Name: "Confetti", Debug: true, Port: 8080}, index["App"])
+}
+
+func Test_load_yaml_file_case_insensitive(t *testing.T) {
+	path := configDir(t, map[string]string{"config.yaml": "app:\n  debug: true\n  timeout: 2m\n"})
+
+	index, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ()}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, appConfig{Name: "Confetti", Debug: true, Port: 80, Timeout: 2 * time.Minute}, index["App"])
+}
+
+func Test_load_toml_file_with_nested_struct(t *testing.T) {
+	path := configDir(t, map[string]string{"config.toml": "[App.Database]\nHost = \"localhost\"\n"})
+
+	index, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ()}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, "localhost", index["App"].(appConfig).Database.Host)
+}
+
+func Test_load_file_of_environment_overrides_file(t *testing.T) {
+	path := configDir(t, map[string]string{
+		"config.yaml":            "App:\n  Name: Default\n  Port: 8000\n",
+		"config.production.yaml": "App:\n  Name: Production\n",
+	})
+
+	index, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ()}.Load("production")
+
+	require.NoError(t, err)
+	require.Equal(t, "Production", index["App"].(appConfig).Name)
+	require.Equal(t, 8000, index["App"].(appConfig).Port)
+}
+
+func Test_load_file_of_other_environment_is_ignored(t *testing.T) {
+	path := configDir(t, map[string]string{"config.production.yaml": "App:\n  Name: Production\n"})
+
+	index, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ()}.Load("testing")
+
+	require.NoError(t, err)
+	require.Equal(t, "Confetti", index["App"].(appConfig).Name)
+}
+
+func Test_load_environment_variables(t *testing.T) {
+	index, err := configLoader.Loader{
+		Defaults: defaults,
+		Environ: environ(
+			"APP_APP__DEBUG=true",
+			"APP_APP__HOSTS=example.com, confetti.dev",
+			"APP_APP__DATABASE__PASSWORD=secret",
+			"OTHER_APP__NAME=ignored",
+		),
+	}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, appConfig{
+		Name:     "Confetti",
+		Debug:    true,
+		Port:     80,
+		Hosts:    []string{"example.com", "confetti.dev"},
+		Database: databaseConfig{Password: "secret"},
+	}, index["App"])
+}
+
+func Test_load_environment_variables_with_prefix(t *testing.T) {
+	index, err := configLoader.Loader{
+		Defaults: defaults,
+		Prefix:   "CONFETTI_",
+		Environ:  environ("CONFETTI_APP__NAME=Prefixed", "APP_APP__PORT=8080"),
+	}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, appConfig{Name: "Prefixed", Port: 80}, index["App"])
+}
+
+func Test_load_dot_env_files(t *testing.T) {
+	path := configDir(t, map[string]string{
+		".env":         "# The name\nAPP_APP__NAME=\"Dot Env\"\nexport APP_APP__PORT=8000 # comment\n",
+		".env.testing": "APP_APP__PORT=9000\n",
+	})
+
+	index, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ()}.Load("testing")
+
+	require.NoError(t, err)
+	require.Equal(t, appConfig{Name: "Dot Env", Port: 9000}, index["App"])
+}
+
+func Test_environment_variables_override_files(t *testing.T) {
+	path := configDir(t, map[string]string{
+		"config.json": `{"App": {"Port": 8000}}`,
+		".env":        "APP_APP__PORT=9000\n",
+	})
+
+	index, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ("APP_APP__PORT=7000")}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, 7000, index["App"].(appConfig).Port)
+}
+
+func Test_load_unknown_key_in_map(t *testing.T) {
+	index, err := configLoader.Loader{Defaults: defaults, Environ: environ("APP_FEATURE=enabled")}.Load("")
+
+	require.NoError(t, err)
+	require.Equal(t, "enabled", index["FEATURE"])
+}
+
+func Test_load_reports_all_invalid_keys(t *testing.T) {
+	path := configDir(t, map[string]string{"config.json": `{"App": {"Port": "eighty", "Unknown": true}}`})
+
+	_, err := configLoader.Loader{
+		Defaults: defaults,
+		Path:     path,
+		Required: []string{"App.Database.Password", "App.Name"},
+		Environ:  environ("APP_APP__DEBUG=maybe"),
+	}.Load("")
+
+	require.True(t, errors.Is(err, configLoader.InvalidConfigurationError))
+	require.Contains(t, err.Error(), `key 'App.Port': can not convert "eighty" to int`)
+	require.Contains(t, err.Error(), "key 'App.Unknown': key does not exist")
+	require.Contains(t, err.Error(), `key 'App.Debug': can not convert "maybe" to bool`)
+	require.Contains(t, err.Error(), "key 'App.Database.Password' is required")
+	require.NotContains(t, err.Error(), "key 'App.Name' is required")
+}
+
+func Test_load_invalid_file(t *testing.T) {
+	path := configDir(t, map[string]string{"config.json": `{"App": `})
+
+	_, err := configLoader.Loader{Defaults: defaults, Path: path, Environ: environ()}.Load("")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can not parse configuration file")
+}
+
+func Test_provider_loads_config_of_environment(t *testing.T) {
+	path := configDir(t, map[string]string{"config.staging.yaml": "App:\n  Name: Staging\n"})
+	var container inter.Container = foundation.NewContainer()
+	container.Bind("env", "staging")
+
+	container = providers.ConfigServiceProvider{
+		Index:  defaults,
+		Loader: &configLoader.Loader{Path: path, Environ: environ("APP_APP__TIMEOUT=5s")},
+	}.Register(container)
+
+	require.Equal(t, "Staging", container.Make("config.App.Name"))
+	repository := container.Make(configLoader.Repository{}).(configLoader.Repository)
+	require.Equal(t, "Staging", repository.String("App.Name"))
+	require.Equal(t, 80, repository.Int("App.Port"))
+	require.Equal(t, 5*time.Second, repository.Duration("App.Timeout"))
+	require.False(t, repository.Bool("App.Debug"))
+}
+
+func Test_provider_panics_with_invalid_config(t *testing.T) {
+	var container inter.Container = foundation.NewContainer()
+
+	require.PanicsWithError(t, "key 'App.Database.Host' is required: invalid configuration", func() {
+		providers.ConfigServiceProvider{
+			Index:  defaults,
+			Loader: &configLoader.Loader{Required: []string{"App.Database.Host"}, Environ: environ()},
+		}.Register(container)
+	})
+}
+
+func Test_repository_get_not_existing_key(t *testing.T) {
+	repository := configLoader.NewRepository(defaults)
+
+	_, err := repository.StringE("App.Missing")
+
+	require.Error(t, err)
+}
+
+func configDir(t *testing.T, files map[string]string) string {
+	path, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(path) })
+
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(path, name), []byte(content), 0644))
+	}
+
+	return path
+}
+
+func environ(variables ...string) func() []string {
+	return func() []string {
+		return variables
+	}
+}
@@ -0,0 +1,190 @@
+package console
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/config"
+	"github.com/confetti-framework/foundation/console"
+	"github.com/confetti-framework/foundation/providers"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_config_show_masks_secrets(t *testing.T) {
+	output, app := setUpConfig(nil, "config:show")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	result := TrimDoubleSpaces(output.String())
+	require.Contains(t, result, "Database.Host localhost")
+	require.Contains(t, result, "Database.Password ********")
+	require.NotContains(t, result, "secret")
+}
+
+func Test_config_show_masks_values_under_sensitive_key(t *testing.T) {
+	output, app := setUpConfig(nil, "config:show")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, TrimDoubleSpaces(output.String()), "Credentials.Stripe.User ********")
+	require.NotContains(t, output.String(), "sk_live_stripe")
+}
+
+func Test_config_show_filter_by_key(t *testing.T) {
+	output, app := setUpConfig(nil, "config:show", "-k", "App")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, TrimDoubleSpaces(output.String()), "App.Name Confetti")
+	require.NotContains(t, output.String(), "Database")
+}
+
+func Test_config_show_as_json(t *testing.T) {
+	output, app := setUpConfig(nil, "config:show", "--json", "--key", "Database")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	var result map[string]interface{}
+	require.Nil(t, json.Unmarshal(bytes.TrimSuffix(output.Bytes(), []byte("\033[39m\n"))[len("\033[39m"):], &result))
+	require.Equal(t, map[string]interface{}{
+		"Database.Host":     "localhost",
+		"Database.Password": config.Masked,
+	}, result)
+}
+
+func Test_config_cache_without_loader(t *testing.T) {
+	output, app := setUpConfig(nil, "config:cache")
+
+	code := console.Kernel{App: app, Writer: &output, WriterErr: &output}.Handle()
+
+	require.Equal(t, inter.Failure, code)
+	require.Contains(t, output.String(), "no configuration cache file configured")
+}
+
+func Test_config_cache_is_loaded_on_boot(t *testing.T) {
+	path, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(path, "config.json"), []byte(`{"Database": {"Host": "db"}}`), 0644))
+	loader := &config.Loader{
+		Path:    path,
+		Cache:   filepath.Join(path, "cache.json"),
+		Environ: func() []string { return []string{"APP_DATABASE__PORT=3306"} },
+	}
+
+	output, app := setUpConfig(loader, "config:cache")
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, output.String(), "Configuration cached in "+loader.Cache)
+
+	// The sources are not used when the cache exists
+	require.NoError(t, os.Remove(filepath.Join(path, "config.json")))
+	loader.Environ = func() []string { return nil }
+	_, app = setUpConfig(loader, "config:cache")
+	require.Equal(t, "db", app.Make("config.Database.Host"))
+	require.Equal(t, "3306", app.Make("config.Database.PORT"))
+}
+
+func Test_config_cache_only_readable_by_owner(t *testing.T) {
+	path, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+	cache := filepath.Join(path, "cache.json")
+	require.NoError(t, ioutil.WriteFile(cache, []byte(`{"environment": "testing", "values": {}}`), 0644))
+	loader := &config.Loader{Path: path, Cache: cache, Environ: func() []string { return nil }}
+
+	output, app := setUpConfig(loader, "config:cache")
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	info, err := os.Stat(cache)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func Test_config_cache_of_other_environment_is_replaced(t *testing.T) {
+	path, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(path, "config.json"), []byte(`{"Database": {"Host": "db"}}`), 0644))
+	cache := filepath.Join(path, "cache.json")
+	require.NoError(t, ioutil.WriteFile(cache, []byte(`{"environment": "production", "values": {}}`), 0600))
+	var warnings []error
+	loader := &config.Loader{
+		Path:    path,
+		Cache:   cache,
+		Environ: func() []string { return nil },
+		Warn:    func(err error) { warnings = append(warnings, err) },
+	}
+
+	output, app := setUpConfig(loader, "config:cache")
+	require.Equal(t, "db", app.Make("config.Database.Host"))
+	require.Len(t, warnings, 1)
+	require.True(t, errors.Is(warnings[0], config.CacheEnvironmentMismatchError))
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	content, err := ioutil.ReadFile(cache)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `"environment": "testing"`)
+}
+
+func Test_config_cache_clear(t *testing.T) {
+	path, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(path)
+	cache := filepath.Join(path, "cache.json")
+	require.NoError(t, ioutil.WriteFile(cache, []byte(`{"environment": "testing", "values": {}}`), 0644))
+
+	output, app := setUpConfig(&config.Loader{Cache: cache}, "config:cache", "--clear")
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, output.String(), "Configuration cache cleared")
+	_, err = os.Stat(cache)
+	require.True(t, os.IsNotExist(err))
+}
+
+func setUpConfig(loader *config.Loader, args ...string) (bytes.Buffer, inter.App) {
+	var writer bytes.Buffer
+
+	osArgs := []interface{}{"/main"}
+	for _, arg := range args {
+		osArgs = append(osArgs, arg)
+	}
+
+	app := foundation.NewTestApp(func(container inter.Container) inter.Container {
+		container.Bind("env", "testing")
+		return providers.ConfigServiceProvider{
+			Index: map[string]interface{}{
+				"App": map[string]interface{}{
+					"Name":   "Confetti",
+					"Env":    "testing",
+					"OsArgs": osArgs,
+				},
+				"Database": map[string]interface{}{
+					"Host":     "localhost",
+					"Password": "secret",
+				},
+				"Credentials": map[string]interface{}{
+					"Stripe": map[string]interface{}{"User": "sk_live_stripe"},
+				},
+			},
+			Loader: loader,
+		}.Register(container)
+	})
+
+	return writer, app
+}
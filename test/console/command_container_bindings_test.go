@@ -0,0 +1,97 @@
+package console
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/console"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type mockMailer struct{}
+
+func Test_container_bindings_get_name(t *testing.T) {
+	require.Equal(t, "container:bindings", console.ContainerBindings{}.Name())
+}
+
+func Test_container_bindings_shows_source_and_type(t *testing.T) {
+	output, app := setUpBindings("container:bindings")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	result := TrimDoubleSpaces(output.String())
+	require.Contains(t, result, "config.App.Name boot value string")
+	require.Contains(t, result, "console.mockMailer boot singleton callback *console.mockMailer")
+	require.Contains(t, result, "current_user boot scoped callback string")
+	require.Contains(t, result, "route request value int")
+}
+
+func Test_container_bindings_request_overrides_boot(t *testing.T) {
+	output, app := setUpBindings("container:bindings", "--prefix", "config.App.Env")
+	app.Bind("config.App.Env", "local")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, TrimDoubleSpaces(output.String()), "config.App.Env request value string")
+}
+
+func Test_container_bindings_filter_by_prefix(t *testing.T) {
+	output, app := setUpBindings("container:bindings", "-p", "current")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, output.String(), "current_user")
+	require.NotContains(t, output.String(), "config.App.Name")
+}
+
+func Test_container_bindings_without_results(t *testing.T) {
+	output, app := setUpBindings("container:bindings", "-p", "non_existing")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, output.String(), "No bindings found")
+}
+
+func Test_container_bindings_as_json(t *testing.T) {
+	output, app := setUpBindings("container:bindings", "--json", "-p", "console.mockMailer")
+
+	code := console.Kernel{App: app, Writer: &output}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	var result []foundation.BindingDescription
+	require.Nil(t, json.Unmarshal(bytes.TrimSuffix(output.Bytes(), []byte("\033[39m\n"))[len("\033[39m"):], &result))
+	require.Equal(t, []foundation.BindingDescription{{
+		Abstract: "console.mockMailer",
+		Source:   "boot",
+		Lifetime: "singleton",
+		Callback: true,
+		Type:     "*console.mockMailer",
+	}}, result)
+}
+
+func setUpBindings(args ...string) (bytes.Buffer, inter.App) {
+	var writer bytes.Buffer
+
+	osArgs := []interface{}{"/main"}
+	for _, arg := range args {
+		osArgs = append(osArgs, arg)
+	}
+
+	app := foundation.NewTestApp(func(container inter.Container) inter.Container {
+		container.Bind("config.App.Name", "Confetti")
+		container.Bind("config.App.Env", "testing")
+		container.Bind("config.App.OsArgs", osArgs)
+		container.Singleton(mockMailer{}, func() *mockMailer { return &mockMailer{} })
+		container.(*foundation.Container).Scoped("current_user", func() string { return "Joop" })
+		return container
+	})
+	app.Bind("route", 1)
+
+	return writer, app
+}
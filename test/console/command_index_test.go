@@ -20,8 +20,9 @@ func Test_index_show_title(t *testing.T) {
 func Test_index_with_one_command(t *testing.T) {
 	output, app := setUp()
 	code := console.Kernel{
-		App:    app,
-		Writer: &output,
+		App:      app,
+		Writer:   &output,
+		Commands: []inter.Command{console.LogClear{}},
 	}.Handle()
 
 	require.Equal(t, inter.Success, code)
@@ -33,7 +34,12 @@ func Test_index_with_one_command(t *testing.T) {
 			" -h --help Can be used with any command to show\n" +
 			" the command's available arguments and options.\n\n" +
 			" baker Interact with your application.\n" +
-			" log:clear Clear the log files as indicated in the configuration.",
+			" config:cache Cache the configuration files and environment variables to speed up booting.\n" +
+			" config:show Show the merged configuration. Sensitive values are masked.\n" +
+			" container:bindings List the bindings of the service container.\n" +
+			" log:clear Clear the log files as indicated in the configuration.\n" +
+			" route:list List the registered routes.\n" +
+			" serve Serve the application over HTTP until the process is stopped.",
 	)
 }
 
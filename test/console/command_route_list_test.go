@@ -0,0 +1,78 @@
+package console
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/console"
+	"github.com/confetti-framework/foundation/console/facade"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type authMiddleware struct{}
+
+func (a authMiddleware) Handle(request inter.Request, next inter.Next) inter.Response {
+	return next(request)
+}
+
+func Test_route_list(t *testing.T) {
+	output, app := setUpRoutes()
+
+	code := console.RouteList{}.Handle(facade.NewCli(app, &output))
+
+	require.Equal(t, inter.Success, code)
+	result := TrimDoubleSpaces(output.String())
+	require.Contains(t, result, "GET /api/users/{id} users.show id: [0-9]+ console.authMiddleware")
+	require.Contains(t, result, "POST api.example.com /api/users users.store")
+}
+
+func Test_route_list_filter(t *testing.T) {
+	output, app := setUpRoutes()
+
+	code := console.RouteList{Method: "post", RouteName: "users", Path: "/api"}.Handle(facade.NewCli(app, &output))
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, output.String(), "users.store")
+	require.NotContains(t, output.String(), "users.show")
+}
+
+func Test_route_list_no_routes_found(t *testing.T) {
+	output, app := setUpRoutes()
+
+	code := console.RouteList{RouteName: "roles"}.Handle(facade.NewCli(app, &output))
+
+	require.Equal(t, inter.Success, code)
+	require.Contains(t, output.String(), "No routes found")
+}
+
+func Test_route_list_as_json(t *testing.T) {
+	output, app := setUpRoutes()
+
+	code := console.RouteList{Method: "GET", Json: true}.Handle(facade.NewCli(app, &output))
+
+	require.Equal(t, inter.Success, code)
+	var result []routing.RouteDescription
+	require.Nil(t, json.Unmarshal(bytes.TrimSuffix(output.Bytes(), []byte("\033[39m\n"))[len("\033[39m"):], &result))
+	require.Equal(t, []routing.RouteDescription{{
+		Method:      "GET",
+		Uri:         "/api/users/{id}",
+		Name:        "users.show",
+		Constraints: map[string]string{"id": "[0-9]+"},
+		Middleware:  []string{"console.authMiddleware"},
+	}}, result)
+}
+
+func setUpRoutes() (bytes.Buffer, inter.App) {
+	var writer bytes.Buffer
+
+	app := foundation.NewApp()
+	app.Bind("routes", routing.Group(
+		routing.Match([]string{"GET"}, "/users/{id}", nil).Where("id", "[0-9]+").Name(".show").Middleware(authMiddleware{}),
+		routing.Post("/users", nil).Domain("api.example.com").Name(".store"),
+	).Prefix("/api").Name("users"))
+
+	return writer, app
+}
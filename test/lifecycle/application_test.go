@@ -87,8 +87,10 @@ func Test_singleton_with_simple_string_callback(t *testing.T) {
 	require.Equal(t, "Joop", appFromJoop.Make("current_user"))
 }
 
+// A singleton of the container created at boot time is shared by all requests.
+// Use Scoped for a result per request.
 func Test_singleton_with_multiple_requests(t *testing.T) {
-	var bootContainer inter.Container = foundation.NewContainer()
+	var bootContainer = foundation.NewContainer()
 	var currentUser = "None"
 
 	bootContainer.Singleton(
@@ -98,6 +100,31 @@ func Test_singleton_with_multiple_requests(t *testing.T) {
 		},
 	)
 
+	// First request
+	var containerFromJoop = foundation.NewContainerByBoot(bootContainer)
+	currentUser = "Joop"
+	expectJoop := containerFromJoop.Make("current_user")
+
+	// Second request
+	var containerFromPiet = foundation.NewContainerByBoot(bootContainer)
+	currentUser = "Piet"
+	expectPiet := containerFromPiet.Make("current_user")
+
+	require.Equal(t, "Joop", expectJoop)
+	require.Equal(t, "Joop", expectPiet)
+}
+
+func Test_scoped_with_multiple_requests(t *testing.T) {
+	var bootContainer = foundation.NewContainer()
+	var currentUser = "None"
+
+	bootContainer.Scoped(
+		"current_user",
+		func() string {
+			return currentUser
+		},
+	)
+
 	// First request
 	var containerFromJoop = foundation.NewContainerByBoot(bootContainer)
 	appFromJoop := &foundation.Application{}
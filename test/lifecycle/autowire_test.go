@@ -0,0 +1,178 @@
+package lifecycle
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type database struct {
+	Dsn string
+}
+
+type userRepository struct {
+	Database *database
+	Logger   testInterface
+}
+
+type userController struct {
+	Users userRepository
+}
+
+func Test_autowire_callback_with_pointer_parameter(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind((*database)(nil), &database{Dsn: "mysql"})
+	container.Singleton(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+
+	repository := container.Make(userRepository{}).(userRepository)
+
+	require.Equal(t, "mysql", repository.Database.Dsn)
+}
+
+func Test_autowire_callback_with_interface_parameter(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind((*testInterface)(nil), testStruct{TestCount: 1})
+	container.Singleton(userRepository{}, func(logger testInterface) userRepository {
+		return userRepository{Logger: logger}
+	})
+
+	repository := container.Make(userRepository{}).(userRepository)
+
+	require.Equal(t, testStruct{TestCount: 1}, repository.Logger)
+}
+
+func Test_autowire_recursive(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind((*database)(nil), &database{Dsn: "mysql"})
+	container.Singleton(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+	container.Singleton(userController{}, func(users userRepository) userController {
+		return userController{Users: users}
+	})
+
+	controller := container.Make(userController{}).(userController)
+
+	require.Equal(t, "mysql", controller.Users.Database.Dsn)
+}
+
+func Test_autowire_from_boot_container(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Bind((*database)(nil), &database{Dsn: "mysql"})
+	container := foundation.NewContainerByBoot(bootContainer)
+	container.Singleton(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+
+	repository := container.Make(userRepository{}).(userRepository)
+
+	require.Equal(t, "mysql", repository.Database.Dsn)
+}
+
+func Test_autowire_container(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind("dsn", "mysql")
+	container.Singleton((*database)(nil), func(container inter.Container) *database {
+		return &database{Dsn: container.Make("dsn").(string)}
+	})
+
+	db := container.Make((*database)(nil)).(*database)
+
+	require.Equal(t, "mysql", db.Dsn)
+}
+
+func Test_autowire_unbound_struct(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton(userController{}, func(users userRepository) userController {
+		return userController{Users: users}
+	})
+
+	controller := container.Make(userController{}).(userController)
+
+	require.Equal(t, userRepository{}, controller.Users)
+}
+
+func Test_autowire_unbound_dependency(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+	container.Singleton(userController{}, func(users userRepository) userController {
+		return userController{Users: users}
+	})
+
+	_, err := container.MakeE(userController{})
+
+	require.True(t, errors.Is(err, foundation.CanNotResolveDependencyError))
+	require.EqualError(
+		t,
+		err,
+		"get instance 'lifecycle.userController' from container: "+
+			"resolve parameter 0 (lifecycle.userRepository) of 'lifecycle.userController': "+
+			"get instance 'lifecycle.userRepository' from container: "+
+			"resolve parameter 0 (*lifecycle.database) of 'lifecycle.userRepository': "+
+			"no binding found for lifecycle.database: can not resolve dependency",
+	)
+}
+
+func Test_autowire_dependency_with_wrong_type(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind((*database)(nil), "mysql")
+	container.Singleton(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+
+	_, err := container.MakeE(userRepository{})
+
+	require.True(t, errors.Is(err, foundation.CanNotResolveDependencyError))
+	require.Contains(t, err.Error(), "'lifecycle.database' resolves to string, which is not assignable to *lifecycle.database")
+}
+
+func Test_autowire_circular_dependency(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton(userRepository{}, func(controller userController) userRepository {
+		return controller.Users
+	})
+	container.Singleton(userController{}, func(users userRepository) userController {
+		return userController{Users: users}
+	})
+
+	_, err := container.MakeE(userController{})
+
+	require.True(t, errors.Is(err, foundation.CircularDependencyError))
+	require.Contains(
+		t,
+		err.Error(),
+		"lifecycle.userController -> lifecycle.userRepository -> lifecycle.userController: circular dependency detected",
+	)
+}
+
+func Test_autowire_circular_dependency_across_boot_container(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Singleton(userRepository{}, func(controller userController) userRepository {
+		return controller.Users
+	})
+	bootContainer.Singleton(userController{}, func(users userRepository) userController {
+		return userController{Users: users}
+	})
+	container := foundation.NewContainerByBoot(bootContainer)
+
+	_, err := container.MakeE(userController{})
+
+	require.True(t, errors.Is(err, foundation.CircularDependencyError))
+}
+
+func Test_autowire_variadic_callback(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton("a_callback", func(names ...string) testStruct {
+		return testStruct{}
+	})
+
+	_, err := container.MakeE("a_callback")
+
+	require.True(t, errors.Is(err, foundation.CanNotInstantiateCallbackWithParameters))
+}
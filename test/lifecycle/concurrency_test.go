@@ -0,0 +1,133 @@
+package lifecycle
+
+import (
+	"github.com/confetti-framework/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const goroutines = 50
+
+func Test_concurrent_make_executes_singleton_once(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	var calls int32
+	bootContainer.Singleton("connection", func() int32 {
+		// Give other goroutines time to wait for the result
+		time.Sleep(10 * time.Millisecond)
+		return atomic.AddInt32(&calls, 1)
+	})
+
+	results := make(chan interface{}, goroutines)
+	parallel(func(i int) {
+		results <- foundation.NewContainerByBoot(bootContainer).Make("connection")
+	})
+	close(results)
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for result := range results {
+		require.Equal(t, int32(1), result)
+	}
+}
+
+func Test_concurrent_make_executes_scoped_once_per_request(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	var calls int32
+	bootContainer.Scoped("current_user", func() int32 {
+		return atomic.AddInt32(&calls, 1)
+	})
+	container := foundation.NewContainerByBoot(bootContainer)
+
+	parallel(func(i int) {
+		container.Make("current_user")
+	})
+
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_concurrent_bind_and_make(t *testing.T) {
+	container := foundation.NewContainerByBoot(foundation.NewContainer())
+
+	parallel(func(i int) {
+		key := "key_" + strconv.Itoa(i)
+		container.Bind(key, i)
+		assert.Equal(t, i, container.Make(key))
+		container.Bindings()
+	})
+
+	require.Len(t, container.Bindings(), goroutines)
+}
+
+func Test_concurrent_singleton_and_make(t *testing.T) {
+	container := foundation.NewContainer()
+
+	parallel(func(i int) {
+		key := "key_" + strconv.Itoa(i)
+		container.Singleton(key, func() int { return i })
+		assert.Equal(t, i, container.Make(key))
+		assert.True(t, container.Bound(key))
+	})
+}
+
+func Test_concurrent_make_with_dependencies(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton((*database)(nil), func() *database {
+		return &database{Dsn: "mysql"}
+	})
+	container.Transient(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+
+	repositories := make(chan userRepository, goroutines)
+	parallel(func(i int) {
+		repositories <- container.Make(userRepository{}).(userRepository)
+	})
+	close(repositories)
+
+	var first *database
+	for repository := range repositories {
+		if first == nil {
+			first = repository.Database
+		}
+		require.Same(t, first, repository.Database)
+	}
+}
+
+func Test_concurrent_extend(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind("counter", 0)
+
+	parallel(func(i int) {
+		container.Extend("counter", func(service interface{}) interface{} {
+			return service.(int) + 1
+		})
+	})
+
+	require.Equal(t, goroutines, container.Make("counter"))
+}
+
+func Test_concurrent_make_by_dotted_key(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind("config", map[string]interface{}{"name": "Confetti"})
+
+	parallel(func(i int) {
+		container.Bind("key_"+strconv.Itoa(i), i)
+		assert.Equal(t, "Confetti", container.Make("config.name"))
+	})
+}
+
+func parallel(callback func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			callback(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -95,7 +95,12 @@ func Test_make_from_singleton_with_parameters(t *testing.T) {
 
 	require.NotNil(t, err)
 	require.Nil(t, newStruct)
-	require.EqualError(t, err, "get instance 'a_callback' from container: Can not instantiate callback with parameters")
+	require.EqualError(
+		t,
+		err,
+		"get instance 'a_callback' from container: resolve parameter 0 (string) of 'a_callback': "+
+			"no binding found for string: can not resolve dependency",
+	)
 }
 
 func Test_resolve_automatically(t *testing.T) {
@@ -0,0 +1,139 @@
+package lifecycle
+
+import (
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type storage interface {
+	Disk() string
+}
+
+type diskStorage struct {
+	Name string
+}
+
+func (d diskStorage) Disk() string {
+	return d.Name
+}
+
+type reportController struct {
+	Storage storage
+}
+
+type photoController struct {
+	Storage storage
+}
+
+type healthCheck struct {
+	Name string
+}
+
+func Test_contextual_binding_with_value(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind((*storage)(nil), diskStorage{Name: "local"})
+	container.Singleton(reportController{}, func(s storage) reportController { return reportController{Storage: s} })
+	container.Singleton(photoController{}, func(s storage) photoController { return photoController{Storage: s} })
+
+	container.When(reportController{}).Needs((*storage)(nil)).Give(diskStorage{Name: "s3"})
+
+	require.Equal(t, "s3", container.Make(reportController{}).(reportController).Storage.Disk())
+	require.Equal(t, "local", container.Make(photoController{}).(photoController).Storage.Disk())
+}
+
+func Test_contextual_binding_with_callback(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton(reportController{}, func(s storage) reportController { return reportController{Storage: s} })
+
+	container.When(reportController{}).Needs((*storage)(nil)).Give(func(database *database) storage {
+		return diskStorage{Name: database.Dsn}
+	})
+	container.Bind((*database)(nil), &database{Dsn: "reports"})
+
+	require.Equal(t, "reports", container.Make(reportController{}).(reportController).Storage.Disk())
+}
+
+func Test_contextual_binding_from_boot_container(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.When(reportController{}).Needs((*storage)(nil)).Give(diskStorage{Name: "s3"})
+	container := foundation.NewContainerByBoot(bootContainer)
+	container.Singleton(reportController{}, func(s storage) reportController { return reportController{Storage: s} })
+
+	require.Equal(t, "s3", container.Make(reportController{}).(reportController).Storage.Disk())
+}
+
+func Test_contextual_binding_with_wrong_type(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton(reportController{}, func(s storage) reportController { return reportController{Storage: s} })
+	container.When(reportController{}).Needs((*storage)(nil)).Give("s3")
+
+	_, err := container.MakeE(reportController{})
+
+	require.True(t, errors.Is(err, foundation.CanNotResolveDependencyError))
+}
+
+func Test_contextual_binding_without_needs(t *testing.T) {
+	container := foundation.NewContainer()
+
+	require.Panics(t, func() {
+		container.When(reportController{}).Give(diskStorage{})
+	})
+}
+
+func Test_tagged_without_abstracts(t *testing.T) {
+	container := foundation.NewContainer()
+
+	require.Equal(t, []interface{}{}, container.Tagged("health_checks"))
+}
+
+func Test_tagged_resolves_all_abstracts(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind("database_check", healthCheck{Name: "database"})
+	container.Singleton("queue_check", func() healthCheck { return healthCheck{Name: "queue"} })
+	container.Tag([]interface{}{"database_check", "queue_check"}, "health_checks")
+
+	require.Equal(
+		t,
+		[]interface{}{healthCheck{Name: "database"}, healthCheck{Name: "queue"}},
+		container.Tagged("health_checks"),
+	)
+}
+
+func Test_tagged_with_multiple_tags(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Bind("database_check", healthCheck{Name: "database"})
+	container.Tag([]interface{}{"database_check"}, "health_checks", "critical")
+
+	require.Len(t, container.Tagged("health_checks"), 1)
+	require.Len(t, container.Tagged("critical"), 1)
+}
+
+func Test_tagged_from_boot_and_request_container(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Bind("database_check", healthCheck{Name: "database"})
+	bootContainer.Tag([]interface{}{"database_check"}, "health_checks")
+
+	app := foundation.NewApp()
+	app.SetContainer(foundation.NewContainerByBoot(bootContainer))
+	app.Bind("queue_check", healthCheck{Name: "queue"})
+	(*app.Container()).(*foundation.Container).Tag([]interface{}{"queue_check", "database_check"}, "health_checks")
+
+	require.Equal(
+		t,
+		[]interface{}{healthCheck{Name: "database"}, healthCheck{Name: "queue"}},
+		app.Tagged("health_checks"),
+	)
+}
+
+func Test_tagged_with_unresolvable_abstract(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton("queue_check", func(db *database) healthCheck { return healthCheck{} })
+	container.Tag([]interface{}{"queue_check"}, "health_checks")
+
+	_, err := container.TaggedE("health_checks")
+
+	require.True(t, errors.Is(err, foundation.CanNotResolveDependencyError))
+	require.Contains(t, err.Error(), "resolve tag 'health_checks'")
+}
@@ -0,0 +1,242 @@
+package lifecycle
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/console"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/confetti-framework/foundation/test/mock"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_booted_hooks_in_registration_order(t *testing.T) {
+	app := foundation.NewApp()
+	var calls []string
+	app.OnBooted(func(app inter.App) { calls = append(calls, "first") })
+	app.OnBooted(func(app inter.App) { calls = append(calls, "second") })
+
+	app.Booted()
+	app.Booted()
+
+	require.Equal(t, []string{"first", "second"}, calls)
+}
+
+func Test_booted_hook_registered_after_boot_runs_immediately(t *testing.T) {
+	app := foundation.NewApp()
+	app.Booted()
+
+	called := false
+	app.OnBooted(func(app inter.App) { called = true })
+
+	require.True(t, called)
+}
+
+func Test_terminate_hooks_in_reverse_registration_order(t *testing.T) {
+	app := foundation.NewApp()
+	var calls []string
+	app.OnTerminated(func(app inter.App) { calls = append(calls, "terminated") })
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		calls = append(calls, "first")
+		return nil
+	})
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		calls = append(calls, "second")
+		return nil
+	})
+
+	require.NoError(t, app.Terminate(context.Background()))
+	require.Equal(t, []string{"second", "first", "terminated"}, calls)
+}
+
+func Test_terminate_only_once(t *testing.T) {
+	app := foundation.NewApp()
+	calls := 0
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		calls++
+		return nil
+	})
+
+	require.NoError(t, app.Terminate(context.Background()))
+	require.NoError(t, app.Terminate(context.Background()))
+	require.Equal(t, 1, calls)
+}
+
+func Test_terminate_returns_errors_of_hooks(t *testing.T) {
+	app := foundation.NewApp()
+	closed := false
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		closed = true
+		return nil
+	})
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		return errors.New("connection already closed")
+	})
+
+	err := app.Terminate(context.Background())
+
+	require.EqualError(t, err, "connection already closed")
+	require.True(t, closed)
+}
+
+func Test_terminate_skips_hooks_after_deadline(t *testing.T) {
+	app := foundation.NewApp()
+	called := false
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		called = true
+		return nil
+	})
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := app.Terminate(ctx)
+
+	require.True(t, errors.Is(err, foundation.TerminateTimeoutError))
+	require.False(t, called)
+}
+
+func Test_terminate_waits_for_requests(t *testing.T) {
+	app := foundation.NewApp()
+	done, err := app.StartRequest()
+	require.NoError(t, err)
+	var calls []string
+	app.OnTerminating(func(ctx context.Context, app inter.App) error {
+		calls = append(calls, "terminating")
+		return nil
+	})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		calls = append(calls, "request")
+		done()
+	}()
+
+	require.NoError(t, app.Terminate(context.Background()))
+	require.Equal(t, []string{"request", "terminating"}, calls)
+}
+
+func Test_terminate_stops_waiting_for_requests_after_deadline(t *testing.T) {
+	app := foundation.NewApp()
+	_, err := app.StartRequest()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = app.Terminate(ctx)
+
+	require.True(t, errors.Is(err, foundation.TerminateTimeoutError))
+}
+
+func Test_requests_refused_after_terminate(t *testing.T) {
+	app := foundation.NewApp()
+	require.NoError(t, app.Terminate(context.Background()))
+
+	_, err := app.StartRequest()
+
+	require.True(t, errors.Is(err, foundation.ApplicationTerminatingError))
+}
+
+func Test_start_requests_while_terminating(t *testing.T) {
+	app := foundation.NewApp()
+	started := make(chan error, 100)
+	for i := 0; i < cap(started); i++ {
+		go func() {
+			done, err := app.StartRequest()
+			if err == nil {
+				done()
+			}
+			started <- err
+		}()
+	}
+
+	require.NoError(t, app.Terminate(context.Background()))
+	for i := 0; i < cap(started); i++ {
+		if err := <-started; err != nil {
+			require.True(t, errors.Is(err, foundation.ApplicationTerminatingError))
+		}
+	}
+}
+
+func Test_hooks_shared_by_requests(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootApp := foundation.NewApp()
+	bootApp.SetContainer(bootContainer)
+	called := false
+	bootApp.OnTerminating(func(ctx context.Context, app inter.App) error {
+		called = true
+		return nil
+	})
+
+	requestApp := foundation.NewApp()
+	requestApp.SetContainer(foundation.NewContainerByBoot(bootContainer))
+
+	require.NoError(t, requestApp.Terminate(context.Background()))
+	require.True(t, called)
+}
+
+func Test_booted_hooks_run_by_console_kernel(t *testing.T) {
+	app := foundation.NewApp()
+	app.Bind("config.App.OsArgs", []interface{}{"/main", "hello"})
+	var calls []string
+	app.OnBooted(func(app inter.App) { calls = append(calls, "booted") })
+
+	code := console.Kernel{App: app, Writer: ioutil.Discard, Commands: []inter.Command{helloCommand{
+		Handler: func() { calls = append(calls, "command") },
+	}}}.Handle()
+
+	require.Equal(t, inter.Success, code)
+	require.Equal(t, []string{"booted", "command"}, calls)
+}
+
+func Test_booted_hooks_run_once_by_http_kernel(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootApp := foundation.NewApp()
+	bootApp.SetContainer(bootContainer)
+	calls := 0
+	bootApp.OnBooted(func(app inter.App) { calls++ })
+	bootApp.Bind("outcome_html_encoders", mock.HtmlEncoders)
+	bootApp.Bind("response_decorators", []inter.ResponseDecorator{})
+	bootApp.Singleton("routes", routing.Group(
+		routing.Get("/users", func(request inter.Request) inter.Response {
+			return outcome.Html("users")
+		}),
+	))
+
+	for i := 0; i < 2; i++ {
+		var app inter.App = foundation.NewApp()
+		app.SetContainer(foundation.NewContainerByBoot(bootContainer))
+		app.Bind((*inter.HttpKernel)(nil), http.Kernel{App: &app})
+		http.HandleHttpKernel(app, httptest.NewRecorder(), httptest.NewRequest(method.Get, "/users", nil))
+	}
+
+	require.Equal(t, 1, calls)
+}
+
+type helloCommand struct {
+	Handler func()
+}
+
+func (h helloCommand) Name() string {
+	return "hello"
+}
+
+func (h helloCommand) Description() string {
+	return "Say hello."
+}
+
+func (h helloCommand) Handle(c inter.Cli) inter.ExitCode {
+	h.Handler()
+	return inter.Success
+}
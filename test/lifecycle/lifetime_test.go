@@ -0,0 +1,171 @@
+package lifecycle
+
+import (
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_singleton_shared_across_requests(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	calls := 0
+	bootContainer.Singleton("connection", func() int {
+		calls++
+		return calls
+	})
+
+	first := foundation.NewContainerByBoot(bootContainer).Make("connection")
+	second := foundation.NewContainerByBoot(bootContainer).Make("connection")
+
+	require.Equal(t, 1, first)
+	require.Equal(t, 1, second)
+	require.Equal(t, 1, calls)
+}
+
+func Test_singleton_created_once_in_boot_container(t *testing.T) {
+	container := foundation.NewContainer()
+	calls := 0
+	container.Singleton("connection", func() int {
+		calls++
+		return calls
+	})
+
+	container.Make("connection")
+	container.Make("connection")
+
+	require.Equal(t, 1, calls)
+}
+
+func Test_singleton_can_not_depend_on_request_binding(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Singleton(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+
+	container := foundation.NewContainerByBoot(bootContainer)
+	container.Bind((*database)(nil), &database{Dsn: "tenant_a"})
+
+	_, err := container.MakeE(userRepository{})
+
+	require.True(t, errors.Is(err, foundation.CanNotResolveDependencyError))
+}
+
+func Test_scoped_created_once_per_request(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	calls := 0
+	bootContainer.Scoped("current_user", func() int {
+		calls++
+		return calls
+	})
+
+	firstRequest := foundation.NewContainerByBoot(bootContainer)
+	secondRequest := foundation.NewContainerByBoot(bootContainer)
+
+	require.Equal(t, 1, firstRequest.Make("current_user"))
+	require.Equal(t, 1, firstRequest.Make("current_user"))
+	require.Equal(t, 2, secondRequest.Make("current_user"))
+	require.Equal(t, 2, calls)
+}
+
+func Test_scoped_depends_on_request_binding(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Scoped(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+
+	container := foundation.NewContainerByBoot(bootContainer)
+	container.Bind((*database)(nil), &database{Dsn: "tenant_a"})
+
+	repository := container.Make(userRepository{}).(userRepository)
+
+	require.Equal(t, "tenant_a", repository.Database.Dsn)
+}
+
+func Test_transient_created_on_every_make(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	calls := 0
+	bootContainer.Transient("mailer", func() int {
+		calls++
+		return calls
+	})
+	container := foundation.NewContainerByBoot(bootContainer)
+
+	require.Equal(t, 1, container.Make("mailer"))
+	require.Equal(t, 2, container.Make("mailer"))
+	require.Equal(t, 3, bootContainer.Make("mailer"))
+}
+
+func Test_transient_from_application(t *testing.T) {
+	app := foundation.NewApp()
+	calls := 0
+	app.Transient("mailer", func() int {
+		calls++
+		return calls
+	})
+
+	app.Make("mailer")
+	app.Make("mailer")
+
+	require.Equal(t, 2, calls)
+}
+
+func Test_singleton_can_not_depend_on_scoped(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Scoped((*database)(nil), func() *database {
+		return &database{}
+	})
+	bootContainer.Singleton(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+	container := foundation.NewContainerByBoot(bootContainer)
+
+	_, err := container.MakeE(userRepository{})
+
+	require.True(t, errors.Is(err, foundation.SingletonDependsOnScopedError))
+	require.Contains(t, err.Error(), "singleton 'lifecycle.userRepository' depends on scoped 'lifecycle.database'")
+}
+
+func Test_singleton_can_not_depend_on_scoped_indirectly(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Scoped((*database)(nil), func() *database {
+		return &database{}
+	})
+	bootContainer.Transient(userRepository{}, func(db *database) userRepository {
+		return userRepository{Database: db}
+	})
+	bootContainer.Singleton(userController{}, func(users userRepository) userController {
+		return userController{Users: users}
+	})
+
+	_, err := bootContainer.MakeE(userController{})
+
+	require.True(t, errors.Is(err, foundation.SingletonDependsOnScopedError))
+}
+
+func Test_register_with_other_lifetime(t *testing.T) {
+	container := foundation.NewContainer()
+	container.Singleton("mailer", func() string { return "singleton" })
+	container.Make("mailer")
+	container.Transient("mailer", func() string { return "transient" })
+
+	lifetime, ok := container.Lifetime("mailer")
+
+	require.True(t, ok)
+	require.Equal(t, foundation.LifetimeTransient, lifetime)
+	require.Equal(t, "transient", container.Make("mailer"))
+}
+
+func Test_lifetime_from_boot_container(t *testing.T) {
+	bootContainer := foundation.NewContainer()
+	bootContainer.Scoped("current_user", func() string { return "Joop" })
+	bootContainer.Bind("name", "Joop")
+	container := foundation.NewContainerByBoot(bootContainer).(*foundation.Container)
+
+	lifetime, ok := container.Lifetime("current_user")
+	require.True(t, ok)
+	require.Equal(t, foundation.LifetimeScoped, lifetime)
+
+	_, ok = container.Lifetime("name")
+	require.False(t, ok)
+}
@@ -0,0 +1,225 @@
+package lifecycle
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/decorator/container_decorator"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type calls []string
+
+type routeProvider struct {
+	calls *calls
+}
+
+func (p routeProvider) Register(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "register routes")
+	return container
+}
+
+func (p routeProvider) Boot(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "boot routes with "+container.Make("database_name").(string))
+	return container
+}
+
+type databaseProvider struct {
+	calls *calls
+}
+
+func (p databaseProvider) Register(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "register database")
+	container.Bind("database_name", "mysql")
+	return container
+}
+
+type mailProvider struct {
+	calls *calls
+}
+
+func (p mailProvider) Register(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "register mail")
+	container.Bind("mailer", "smtp")
+	container.Bind("mail_queue", "default")
+	return container
+}
+
+func (p mailProvider) Boot(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "boot mail")
+	return container
+}
+
+func (p mailProvider) Provides() []interface{} {
+	return []interface{}{"mailer", "mail_queue"}
+}
+
+// Provides an abstract that Register doesn't bind
+type queueProvider struct {
+	calls *calls
+}
+
+func (p queueProvider) Register(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "register queue")
+	container.Bind("queue", "redis")
+	return container
+}
+
+func (p queueProvider) Boot(container inter.Container) inter.Container {
+	if _, err := container.MakeE("queue_connection"); err != nil {
+		*p.calls = append(*p.calls, "boot queue without connection")
+	}
+	return container
+}
+
+func (p queueProvider) Provides() []interface{} {
+	return []interface{}{"queue", "queue_connection"}
+}
+
+// Registers in another container than the given container
+type cacheProvider struct {
+	calls *calls
+}
+
+func (p cacheProvider) Register(_ inter.Container) inter.Container {
+	registered := foundation.NewContainer()
+	registered.Bind("cache", "file")
+	registered.Singleton("cache_path", func() string { return "/tmp/cache" })
+	return registered
+}
+
+func (p cacheProvider) Boot(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "boot cache in "+container.Make("cache_path").(string))
+	return container
+}
+
+func (p cacheProvider) Provides() []interface{} {
+	return []interface{}{"cache", "cache_path"}
+}
+
+type bootOnlyProvider struct {
+	calls *calls
+}
+
+func (p bootOnlyProvider) Boot(container inter.Container) inter.Container {
+	*p.calls = append(*p.calls, "boot only")
+	return container
+}
+
+type bootstrapper struct {
+	calls *calls
+}
+
+func (b bootstrapper) Bootstrap(container inter.Container) inter.Container {
+	*b.calls = append(*b.calls, "bootstrap")
+	return container
+}
+
+func Test_register_all_providers_before_boot(t *testing.T) {
+	called := &calls{}
+
+	container_decorator.Handler{
+		Bootstraps: []inter.Bootstrap{bootstrapper{calls: called}},
+		Providers: []interface{}{
+			routeProvider{calls: called},
+			bootOnlyProvider{calls: called},
+			databaseProvider{calls: called},
+		},
+	}.BootstrapWith(foundation.NewContainer())
+
+	require.Equal(t, calls{
+		"bootstrap",
+		"register routes",
+		"register database",
+		"boot routes with mysql",
+		"boot only",
+	}, *called)
+}
+
+func Test_deferred_provider_not_registered_at_boot(t *testing.T) {
+	called := &calls{}
+
+	container := container_decorator.Handler{
+		Providers: []interface{}{mailProvider{calls: called}},
+	}.BootstrapWith(foundation.NewContainer())
+
+	require.Empty(t, *called)
+	require.True(t, container.Bound("mailer"))
+}
+
+func Test_deferred_provider_registered_when_resolved(t *testing.T) {
+	called := &calls{}
+	container := container_decorator.Handler{
+		Providers: []interface{}{mailProvider{calls: called}},
+	}.BootstrapWith(foundation.NewContainer())
+
+	require.Equal(t, "smtp", container.Make("mailer"))
+	require.Equal(t, "default", container.Make("mail_queue"))
+	require.Equal(t, calls{"register mail", "boot mail"}, *called)
+}
+
+func Test_deferred_provider_resolved_from_request_container(t *testing.T) {
+	called := &calls{}
+	bootContainer := container_decorator.Handler{
+		Providers: []interface{}{mailProvider{calls: called}},
+	}.BootstrapWith(foundation.NewContainer())
+
+	first := foundation.NewContainerByBoot(bootContainer)
+	second := foundation.NewContainerByBoot(bootContainer)
+
+	require.Equal(t, "smtp", first.Make("mailer"))
+	require.Equal(t, "default", second.Make("mail_queue"))
+	require.Equal(t, calls{"register mail", "boot mail"}, *called)
+}
+
+func Test_deferred_provider_overwritten_by_binding(t *testing.T) {
+	called := &calls{}
+	container := container_decorator.Handler{
+		Providers: []interface{}{mailProvider{calls: called}},
+	}.BootstrapWith(foundation.NewContainer())
+
+	container.Bind("mailer", "log")
+
+	require.Equal(t, "log", container.Make("mailer"))
+	require.Equal(t, "default", container.Make("mail_queue"))
+}
+
+func Test_provider_without_register_or_boot(t *testing.T) {
+	require.PanicsWithError(t, "provider lifecycle.testStruct has no Register or Boot method", func() {
+		container_decorator.Handler{
+			Providers: []interface{}{testStruct{}},
+		}.BootstrapWith(foundation.NewContainer())
+	})
+}
+
+func Test_deferred_provider_resolves_own_abstract_on_boot(t *testing.T) {
+	called := &calls{}
+	container := container_decorator.Handler{
+		Providers: []interface{}{queueProvider{calls: called}},
+	}.BootstrapWith(foundation.NewContainer())
+
+	resolved := make(chan interface{})
+	go func() {
+		resolved <- container.Make("queue")
+	}()
+
+	select {
+	case queue := <-resolved:
+		require.Equal(t, "redis", queue)
+	case <-time.After(time.Second):
+		t.Fatal("the deferred provider did not finish booting")
+	}
+	require.Equal(t, calls{"register queue", "boot queue without connection"}, *called)
+}
+
+func Test_deferred_provider_with_registered_container(t *testing.T) {
+	called := &calls{}
+	container := container_decorator.Handler{
+		Providers: []interface{}{cacheProvider{calls: called}},
+	}.BootstrapWith(foundation.NewContainer())
+
+	require.Equal(t, "file", container.Make("cache"))
+	require.Equal(t, "/tmp/cache", container.Make("cache_path"))
+	require.Equal(t, calls{"boot cache in /tmp/cache"}, *called)
+}
@@ -0,0 +1,97 @@
+package request
+
+import (
+	"context"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/middleware"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/confetti-framework/foundation/test/mock"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"testing"
+)
+
+type contextKey string
+
+type setUserInContext struct{}
+
+func (s setUserInContext) Handle(request inter.Request, next inter.Next) inter.Response {
+	ctx := context.WithValue(http.Context(request), contextKey("user"), "Jip")
+	return next(request.(http.ContextRequest).SetContext(ctx))
+}
+
+func Test_request_context_from_source(t *testing.T) {
+	ctx := context.WithValue(context.Background(), contextKey("trace"), "123")
+	source := httptest.NewRequest(method.Get, "/", nil).WithContext(ctx)
+
+	request := http.NewRequest(http.Options{Source: *source})
+
+	require.Equal(t, "123", http.Context(request).Value(contextKey("trace")))
+}
+
+func Test_request_context_without_source(t *testing.T) {
+	request := http.NewRequest(http.Options{Method: method.Get})
+
+	require.NoError(t, http.Context(request).Err())
+}
+
+func Test_middleware_can_replace_context(t *testing.T) {
+	request := http.NewRequest(http.Options{App: foundation.NewApp(), Method: method.Get})
+
+	response := middleware.NewPipeline(request.App()).
+		Send(request).
+		Through([]inter.HttpMiddleware{setUserInContext{}}).
+		Then(func(request inter.Request) inter.Response {
+			return outcome.Content(http.Context(request).Value(contextKey("user")))
+		})
+
+	require.Equal(t, "Jip", response.GetContent())
+}
+
+func Test_controller_is_not_called_when_client_closed_request(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	source := httptest.NewRequest(method.Get, "/users", nil).WithContext(ctx)
+	request := http.NewRequest(http.Options{App: foundation.NewApp(), Source: *source})
+	var called bool
+
+	defer func() {
+		err := recover().(error)
+		require.True(t, errors.Is(err, middleware.ClientClosedRequestError))
+		status, _ := errors.FindStatus(err)
+		require.Equal(t, middleware.StatusClientClosedRequest, status)
+		require.False(t, called)
+	}()
+
+	middleware.NewPipeline(request.App()).
+		Send(request).
+		Then(func(request inter.Request) inter.Response {
+			called = true
+			return outcome.Content("")
+		})
+}
+
+func Test_kernel_does_not_respond_when_client_closed_request(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var app inter.App = foundation.NewApp()
+	app.Bind("outcome_html_encoders", mock.HtmlEncoders)
+	app.Bind("response_decorators", []inter.ResponseDecorator{})
+	app.Bind((*inter.HttpKernel)(nil), http.Kernel{App: &app})
+	app.Singleton("routes", routing.Group(
+		routing.Get("/users", func(request inter.Request) inter.Response {
+			// The client leaves while the controller is working
+			cancel()
+			return outcome.Html("users")
+		}),
+	))
+	recorder := httptest.NewRecorder()
+
+	http.HandleHttpKernel(app, recorder, httptest.NewRequest(method.Get, "/users", nil).WithContext(ctx))
+
+	require.Empty(t, recorder.Body.String())
+}
@@ -0,0 +1,119 @@
+package response
+
+import (
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	net "net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func Test_download_full_file(t *testing.T) {
+	file := downloadFile(t)
+
+	recorder := handleDownload(file, nil)
+
+	require.Equal(t, net.StatusOK, recorder.Code)
+	require.Equal(t, "0123456789", recorder.Body.String())
+	require.Equal(t, "bytes", recorder.Header().Get("Accept-Ranges"))
+	require.NotEmpty(t, recorder.Header().Get("ETag"))
+	require.NotEmpty(t, recorder.Header().Get("Last-Modified"))
+	require.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+}
+
+func Test_download_range(t *testing.T) {
+	file := downloadFile(t)
+
+	recorder := handleDownload(file, map[string]string{"Range": "bytes=2-5"})
+
+	require.Equal(t, net.StatusPartialContent, recorder.Code)
+	require.Equal(t, "2345", recorder.Body.String())
+	require.Equal(t, "bytes 2-5/10", recorder.Header().Get("Content-Range"))
+	require.Equal(t, "4", recorder.Header().Get("Content-Length"))
+}
+
+func Test_download_multiple_ranges(t *testing.T) {
+	file := downloadFile(t)
+
+	recorder := handleDownload(file, map[string]string{"Range": "bytes=0-1,8-9"})
+
+	require.Equal(t, net.StatusPartialContent, recorder.Code)
+	mediaType, params, err := mime.ParseMediaType(recorder.Header().Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/byteranges", mediaType)
+
+	reader := multipart.NewReader(recorder.Body, params["boundary"])
+	var parts []string
+	for part, err := reader.NextPart(); err == nil; part, err = reader.NextPart() {
+		content, _ := ioutil.ReadAll(part)
+		parts = append(parts, string(content))
+	}
+	require.Equal(t, []string{"01", "89"}, parts)
+}
+
+func Test_download_not_modified_by_etag(t *testing.T) {
+	file := downloadFile(t)
+	etag := handleDownload(file, nil).Header().Get("ETag")
+
+	recorder := handleDownload(file, map[string]string{"If-None-Match": etag})
+
+	require.Equal(t, net.StatusNotModified, recorder.Code)
+	require.Empty(t, recorder.Body.String())
+}
+
+func Test_download_not_modified_since(t *testing.T) {
+	file := downloadFile(t)
+	modified := time.Now().Add(time.Hour).UTC().Format(net.TimeFormat)
+
+	recorder := handleDownload(file, map[string]string{"If-Modified-Since": modified})
+
+	require.Equal(t, net.StatusNotModified, recorder.Code)
+}
+
+func Test_download_range_ignored_when_if_range_does_not_match(t *testing.T) {
+	file := downloadFile(t)
+
+	recorder := handleDownload(file, map[string]string{"Range": "bytes=2-5", "If-Range": `"outdated"`})
+
+	require.Equal(t, net.StatusOK, recorder.Code)
+	require.Equal(t, "0123456789", recorder.Body.String())
+}
+
+func Test_download_range_with_matching_if_range(t *testing.T) {
+	file := downloadFile(t)
+	etag := handleDownload(file, nil).Header().Get("ETag")
+
+	recorder := handleDownload(file, map[string]string{"Range": "bytes=2-5", "If-Range": etag})
+
+	require.Equal(t, net.StatusPartialContent, recorder.Code)
+	require.Equal(t, "2345", recorder.Body.String())
+}
+
+func downloadFile(t *testing.T) string {
+	file, err := ioutil.TempFile("", "download")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.Remove(file.Name()) })
+	_, err = file.WriteString("0123456789")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	return file.Name()
+}
+
+func handleDownload(file string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest("GET", "/download", nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	recorder := httptest.NewRecorder()
+	http.HandleHttpKernel(newApp(outcome.Download(file)), recorder, request)
+
+	return recorder
+}
@@ -0,0 +1,80 @@
+package response
+
+import (
+	"context"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/stretchr/testify/require"
+	net "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_event_stream_headers(t *testing.T) {
+	recorder := handleResponse(outcome.EventStream(func(sender *outcome.EventSender) error {
+		return nil
+	}))
+
+	require.Equal(t, net.StatusOK, recorder.Code)
+	require.Equal(t, "text/event-stream", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "no-cache", recorder.Header().Get("Cache-Control"))
+}
+
+func Test_event_stream_events(t *testing.T) {
+	recorder := handleResponse(outcome.EventStream(func(sender *outcome.EventSender) error {
+		_ = sender.Send(outcome.Event{Data: "started"})
+		_ = sender.Comment("keep alive")
+		return sender.Send(outcome.Event{
+			Id:    "2",
+			Event: "progress",
+			Data:  map[string]int{"percentage": 50},
+			Retry: 3 * time.Second,
+		})
+	}))
+
+	require.Equal(t, "data: started\n\n"+
+		": keep alive\n\n"+
+		"id: 2\nevent: progress\nretry: 3000\ndata: {\"percentage\":50}\n\n",
+		recorder.Body.String(),
+	)
+	require.True(t, recorder.Flushed)
+}
+
+func Test_event_stream_multiline_data(t *testing.T) {
+	response := outcome.EventStream(func(sender *outcome.EventSender) error {
+		return sender.Send(outcome.Event{Event: "multi\nline", Data: "first\r\nsecond"})
+	})
+
+	require.Equal(t, "event: multiline\ndata: first\ndata: second\n\n", response.GetBody())
+}
+
+func Test_event_stream_stops_when_client_disconnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	request := httptest.NewRequest("GET", "/progress", nil).WithContext(ctx)
+	var sendErr error
+	response := outcome.EventStream(func(sender *outcome.EventSender) error {
+		_ = sender.Send(outcome.Event{Data: "first"})
+		cancel()
+		<-sender.Context().Done()
+		sendErr = sender.Send(outcome.Event{Data: "second"})
+		return sendErr
+	})
+
+	recorder := httptest.NewRecorder()
+	http.HandleHttpKernel(newApp(response), recorder, request)
+
+	require.Equal(t, context.Canceled, sendErr)
+	require.Equal(t, "data: first\n\n", recorder.Body.String())
+}
+
+func Test_event_stream_keeps_status_and_headers(t *testing.T) {
+	response := outcome.EventStream(func(sender *outcome.EventSender) error {
+		return nil
+	}).Header("X-Channel", "orders").Status(net.StatusAccepted)
+
+	recorder := handleResponse(response)
+
+	require.Equal(t, net.StatusAccepted, recorder.Code)
+	require.Equal(t, "orders", recorder.Header().Get("X-Channel"))
+}
@@ -0,0 +1,126 @@
+package response
+
+import (
+	"bytes"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/stretchr/testify/require"
+	"io"
+	"io/ioutil"
+	net "net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_stream_reader(t *testing.T) {
+	recorder := handleResponse(outcome.Stream(strings.NewReader("streamed body")))
+
+	require.Equal(t, net.StatusOK, recorder.Code)
+	require.Equal(t, "streamed body", recorder.Body.String())
+}
+
+func Test_stream_closes_reader(t *testing.T) {
+	reader := &closableReader{Reader: strings.NewReader("body")}
+
+	handleResponse(outcome.Stream(reader))
+
+	require.True(t, reader.closed)
+}
+
+func Test_stream_func_flushes_each_write(t *testing.T) {
+	var flushedWhileWriting bool
+	var recorder *httptest.ResponseRecorder
+	response := outcome.StreamFunc(func(writer io.Writer) error {
+		_, _ = io.WriteString(writer, "first ")
+		flushedWhileWriting = recorder.Flushed
+		_, err := io.WriteString(writer, "second")
+		return err
+	})
+	response.Header("Content-Type", "text/csv")
+
+	recorder = httptest.NewRecorder()
+	http.HandleHttpKernel(newApp(response), recorder, httptest.NewRequest("GET", "/export", nil))
+
+	require.True(t, flushedWhileWriting)
+	require.Equal(t, "first second", recorder.Body.String())
+	require.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+}
+
+func Test_stream_error_aborts_response(t *testing.T) {
+	response := outcome.StreamFunc(func(writer io.Writer) error {
+		return errors.New("disk error")
+	})
+
+	require.PanicsWithValue(t, net.ErrAbortHandler, func() {
+		handleResponse(response)
+	})
+}
+
+func Test_stream_body_as_string(t *testing.T) {
+	response := outcome.Stream(bytes.NewBufferString("body"))
+
+	require.Equal(t, "body", response.GetBody())
+}
+
+func Test_stream_response_keeps_streaming_when_chained(t *testing.T) {
+	response := outcome.Stream(strings.NewReader("body")).Status(net.StatusCreated).ShowInBrowser()
+
+	recorder := handleResponse(response)
+
+	require.Equal(t, net.StatusCreated, recorder.Code)
+	require.Equal(t, "body", recorder.Body.String())
+}
+
+func Test_download_streams_file(t *testing.T) {
+	file, err := ioutil.TempFile("", "export")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(strings.Repeat("a", 100000))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	recorder := handleResponse(outcome.Download(file.Name()))
+
+	require.Equal(t, 100000, recorder.Body.Len())
+	require.Equal(t, "100000", recorder.Header().Get("Content-Length"))
+}
+
+type closableReader struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closableReader) Close() error {
+	c.closed = true
+	return nil
+}
+
+type responseKernel struct {
+	response inter.Response
+}
+
+func (k responseKernel) Handle(_ inter.Request) inter.Response {
+	return k.response
+}
+
+func (k responseKernel) RecoverFromMiddlewarePanic(recover interface{}) inter.Response {
+	panic(recover)
+}
+
+func newApp(response inter.Response) inter.App {
+	app := foundation.NewApp()
+	app.Bind((*inter.HttpKernel)(nil), responseKernel{response: response})
+	return app
+}
+
+func handleResponse(response inter.Response) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	http.HandleHttpKernel(newApp(response), recorder, httptest.NewRequest("GET", "/", nil))
+
+	return recorder
+}
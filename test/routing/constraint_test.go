@@ -0,0 +1,127 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/stretchr/testify/require"
+	net "net/http"
+	"testing"
+)
+
+func Test_named_constraints(t *testing.T) {
+	tests := []struct {
+		routes  inter.RouteCollection
+		match   string
+		noMatch string
+	}{
+		{routing.Get("/users/{id}", emptyController()).WhereNumber("id"), "/users/12", "/users/a12"},
+		{routing.Get("/users/{name}", emptyController()).WhereAlpha("name"), "/users/Jip", "/users/jip1"},
+		{routing.Get("/users/{name}", emptyController()).WhereAlphaNumeric("name"), "/users/jip1", "/users/jip-1"},
+		{routing.Get("/users/{id}", emptyController()).WhereUuid("id"), "/users/123e4567-e89b-12d3-a456-426614174000", "/users/123e4567"},
+		{routing.Get("/photos/{size}", emptyController()).WhereIn("size", "small", "x.large"), "/photos/x.large", "/photos/xalarge"},
+	}
+
+	for _, test := range tests {
+		routes := test.routes.Name("matched")
+		require.Equal(t, "matched", routes.Match(newRequest(http.Options{Method: method.Get, Url: test.match})).Name(), test.match)
+		require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: test.noMatch})).Name(), test.noMatch)
+	}
+}
+
+func Test_constraint_on_parameter_used_multiple_times(t *testing.T) {
+	uri := "/{a}/{a}/{a}/{a}/{a}/{a}/{a}/{a}/{a}/{a}/{a}"
+	routes := routing.Get(uri, emptyController()).Where("a", "[0-9]+").Name("matched")
+
+	require.Equal(t, "matched", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/1/1/1/1/1/1/1/1/1/1/1"})).Name())
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/1/1/1/1/1/1/1/1/1/1/x"})).Name())
+}
+
+func Test_constraint_on_optional_parameter(t *testing.T) {
+	routes := routing.Get("/users/{id?}", emptyController()).Where("id", "[0-9]+").Name("matched")
+
+	require.Equal(t, "matched", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/"})).Name())
+	require.Equal(t, "matched", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"})).Name())
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/jip"})).Name())
+}
+
+func Test_global_pattern(t *testing.T) {
+	routes := routing.Group(
+		routing.Get("/users/{id}", emptyController()).Name("users"),
+		routing.Get("/posts/{id}", emptyController()).Where("id", "[a-z]+").Name("posts"),
+	)
+	routes.Pattern("id", routing.PatternNumber)
+	// Routes added later also receive the pattern
+	routes.Merge(routing.Get("/roles/{id}", emptyController()).Name("roles"))
+
+	require.Equal(t, "users", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"})).Name())
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/jip"})).Name())
+	require.Equal(t, "posts", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/posts/jip"})).Name())
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/roles/jip"})).Name())
+}
+
+func Test_pattern_of_group_only_applies_to_group(t *testing.T) {
+	users := routing.Group(routing.Get("/users/{id}", emptyController()).Name("users"))
+	users.Pattern("id", routing.PatternNumber)
+	routes := routing.Group(
+		users,
+		routing.Get("/posts/{id}", emptyController()).Name("posts"),
+	)
+
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/jip"})).Name())
+	require.Equal(t, "users", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"})).Name())
+	require.Equal(t, "posts", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/posts/jip"})).Name())
+}
+
+func Test_where_in_without_values(t *testing.T) {
+	defer func() {
+		err := recover().(error)
+		require.True(t, errors.Is(err, routing.InvalidConstraintError))
+		require.Equal(t, "no values given for parameter size: invalid route constraint", err.Error())
+	}()
+
+	routing.Get("/photos/{size}", emptyController()).WhereIn("size")
+}
+
+func Test_parameter_int(t *testing.T) {
+	request := matchedRequest("/users/{id}", "/users/12")
+
+	require.Equal(t, 12, routing.ParameterInt(request, "id"))
+}
+
+func Test_parameter_int_invalid(t *testing.T) {
+	request := matchedRequest("/users/{id}", "/users/jip")
+
+	_, err := routing.ParameterIntE(request, "id")
+
+	require.True(t, errors.Is(err, routing.InvalidParameterError))
+	status, _ := errors.FindStatus(err)
+	require.Equal(t, net.StatusNotFound, status)
+	require.Equal(t, "parameter id must be a number, 'jip' given: invalid URL parameter", err.Error())
+}
+
+func Test_parameter_uuid(t *testing.T) {
+	request := matchedRequest("/users/{id}", "/users/123E4567-E89B-12D3-A456-426614174000")
+
+	uuid := routing.ParameterUuid(request, "id")
+
+	require.Equal(t, "123e4567-e89b-12d3-a456-426614174000", uuid.String())
+	require.Equal(t, byte(0x12), uuid[0])
+}
+
+func Test_parameter_uuid_invalid(t *testing.T) {
+	request := matchedRequest("/users/{id}", "/users/12")
+
+	require.Panics(t, func() {
+		routing.ParameterUuid(request, "id")
+	})
+}
+
+func matchedRequest(uri string, url string) inter.Request {
+	request := newRequest(http.Options{Method: method.Get, Url: url})
+	routing.Get(uri, emptyController()).Match(request)
+
+	return request
+}
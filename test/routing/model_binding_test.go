@@ -0,0 +1,116 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/middleware"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/stretchr/testify/require"
+	net "net/http"
+	"testing"
+)
+
+type user struct {
+	Name string
+}
+
+type userRepository map[string]*user
+
+func (r userRepository) Find(id string) (interface{}, error) {
+	return r[id], nil
+}
+
+var resolveUser = routing.FindWith(userRepository{})
+
+func Test_route_model_binding(t *testing.T) {
+	routes := routing.Group(
+		routing.Get("/users/{user}", func(request inter.Request) inter.Response {
+			return outcome.Html(routing.Model(request, "user").(*user).Name)
+		}),
+	).Bind("user", resolveUser)
+
+	request := newUserRequest("/users/1")
+	response := routes.Match(request).Controller()(request)
+
+	require.Equal(t, "Jip", response.GetContent())
+}
+
+func Test_route_model_not_found(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{user}", emptyController())).Bind("user", resolveUser)
+
+	request := newUserRequest("/users/2")
+	controller := routes.Match(request).Controller()
+
+	defer func() {
+		err := recover().(error)
+		require.True(t, errors.Is(err, routing.ModelNotFoundError))
+		status, _ := errors.FindStatus(err)
+		require.Equal(t, net.StatusNotFound, status)
+		require.Equal(t, "no user found for '2': no model found for the specified URL", err.Error())
+	}()
+	controller(request)
+}
+
+func Test_route_model_resolver_with_error(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{user}", emptyController())).
+		Bind("user", func(request inter.Request, value string) (interface{}, error) {
+			return nil, errors.New("database is down")
+		})
+
+	request := newUserRequest("/users/1")
+
+	require.PanicsWithError(t, "resolve user '1': database is down", func() {
+		routes.Match(request).Controller()(request)
+	})
+}
+
+func Test_route_model_with_invalid_finder(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{user}", emptyController())).Bind("user", routing.FindWith("user_finder"))
+
+	request := newUserRequest("/users/1")
+	request.App().Bind("user_finder", "not a finder")
+
+	require.PanicsWithError(t, "resolve user '1': user_finder (string) does not implement routing.Finder: no model resolved", func() {
+		routes.Match(request).Controller()(request)
+	})
+}
+
+func Test_route_model_resolved_after_middleware(t *testing.T) {
+	resolved := false
+	routes := routing.Group(routing.Get("/users/{user}", emptyController()).Middleware(rejectWithoutToken{})).
+		Bind("user", func(request inter.Request, value string) (interface{}, error) {
+			resolved = true
+			return nil, nil
+		})
+
+	request := newUserRequest("/users/2?token=wrong")
+	route := routes.Match(request)
+	response := middleware.NewPipeline(request.App()).
+		Send(request).
+		Through(route.Middleware()).
+		Then(route.Controller())
+
+	require.Equal(t, net.StatusUnauthorized, response.GetStatus())
+	require.False(t, resolved)
+}
+
+func Test_route_model_with_optional_parameter(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{user?}", emptyController()).Name("users")).Bind("user", resolveUser)
+
+	request := newUserRequest("/users/")
+	route := routes.Match(request)
+
+	require.Equal(t, "users", route.Name())
+	_, err := routing.ModelE(request, "user")
+	require.True(t, errors.Is(err, routing.ModelNotResolvedError))
+}
+
+func newUserRequest(url string) inter.Request {
+	request := newRequest(http.Options{Method: method.Get, Url: url})
+	request.App().Bind(userRepository{}, userRepository{"1": {Name: "Jip"}})
+
+	return request
+}
@@ -75,7 +75,7 @@ func Test_no_file_found_without_returning_error(t *testing.T) {
 func Test_existing_file_without_returning_error(t *testing.T) {
 	dir := caller.CurrentDir()
 	result := outcome.Download(dir + "/mock_file.md")
-	require.Equal(t, "# Mock File", result.GetContent())
+	require.Equal(t, "# Mock File", result.GetBody())
 	require.Equal(t, `attachment; filename="mock_file.md"`, result.GetHeader("Content-Disposition"))
 }
 
@@ -91,7 +91,7 @@ func Test_outcome_download_content_by_valid_file(t *testing.T) {
 	dir := caller.CurrentDir()
 	result, err := outcome.DownloadE(dir + "/mock_file.md")
 	require.Nil(t, err)
-	require.Equal(t, "# Mock File", result.GetContent())
+	require.Equal(t, "# Mock File", result.GetBody())
 	require.Equal(t, `attachment; filename="mock_file.md"`, result.GetHeader("Content-Disposition"))
 }
 
@@ -0,0 +1,133 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func photoController() routing.ResourceController {
+	return routing.ResourceController{
+		Index:   actionController("index"),
+		Create:  actionController("create"),
+		Store:   actionController("store"),
+		Show:    actionController("show"),
+		Edit:    actionController("edit"),
+		Update:  actionController("update"),
+		Destroy: actionController("destroy"),
+	}
+}
+
+func actionController(action string) inter.Controller {
+	return func(request inter.Request) inter.Response {
+		return outcome.Html(action)
+	}
+}
+
+func Test_resource_routes(t *testing.T) {
+	routes := routing.Resource("photos", photoController())
+
+	tests := []struct{ method, url, name string }{
+		{method.Get, "/photos", "photos.index"},
+		{method.Get, "/photos/create", "photos.create"},
+		{method.Post, "/photos", "photos.store"},
+		{method.Get, "/photos/12", "photos.show"},
+		{method.Get, "/photos/12/edit", "photos.edit"},
+		{method.Put, "/photos/12", "photos.update"},
+		{method.Patch, "/photos/12", "photos.update"},
+		{method.Delete, "/photos/12", "photos.destroy"},
+	}
+	for _, test := range tests {
+		request := newRequest(http.Options{Method: test.method, Url: test.url})
+		route := routes.Match(request)
+		require.Equal(t, test.name, route.Name(), test.method+" "+test.url)
+	}
+
+	request := newRequest(http.Options{Method: method.Get, Url: "/photos/12"})
+	routes.Match(request)
+	require.Equal(t, "12", request.Parameter("photo").String())
+}
+
+func Test_api_resource_without_forms(t *testing.T) {
+	routes := routing.ApiResource("photos", photoController())
+
+	var names []string
+	for _, route := range routes.All() {
+		if route.Method() != method.Head {
+			names = append(names, route.Name())
+		}
+	}
+
+	require.Equal(t, []string{"photos.index", "photos.store", "photos.show", "photos.update", "photos.update", "photos.destroy"}, names)
+}
+
+func Test_resource_only_and_except(t *testing.T) {
+	only := routing.Resource("photos", photoController(), routing.ResourceOptions{Only: []string{"index", "show"}})
+	except := routing.Resource("photos", photoController(), routing.ResourceOptions{Except: []string{"destroy", "update", "edit", "create", "store"}})
+
+	require.Equal(t, resourceNames(only), resourceNames(except))
+	require.Equal(t, []string{"photos.index", "photos.show"}, resourceNames(only))
+}
+
+func Test_resource_without_controller(t *testing.T) {
+	routes := routing.Resource("photos", routing.ResourceController{Index: actionController("index")})
+
+	require.Equal(t, []string{"photos.index"}, resourceNames(routes))
+}
+
+func Test_nested_resource(t *testing.T) {
+	routes := routing.Resource("photos.comments", photoController())
+	request := newRequest(http.Options{Method: method.Get, Url: "/photos/12/comments/3/edit"})
+
+	route := routes.Match(request)
+
+	require.Equal(t, "photos.comments.edit", route.Name())
+	require.Equal(t, "12", request.Parameter("photo").String())
+	require.Equal(t, "3", request.Parameter("comment").String())
+}
+
+func Test_resource_with_custom_parameter(t *testing.T) {
+	routes := routing.Resource("categories", photoController(), routing.ResourceOptions{Parameter: "slug"})
+	request := newRequest(http.Options{Method: method.Get, Url: "/categories/shoes"})
+
+	routes.Match(request)
+
+	require.Equal(t, "shoes", request.Parameter("slug").String())
+}
+
+func Test_resource_with_group_modifiers(t *testing.T) {
+	routes := routing.Group(
+		routing.Resource("photos", photoController()),
+	).Prefix("/admin").Name("admin.").Middleware(MockedMiddleware1{})
+
+	request := newRequest(http.Options{Method: method.Delete, Url: "/admin/photos/12"})
+	route := routes.Match(request)
+
+	require.Equal(t, "admin.photos.destroy", route.Name())
+	require.Len(t, route.Middleware(), 1)
+}
+
+func Test_resource_url_by_name(t *testing.T) {
+	app := foundation.NewApp()
+	app.Singleton("routes", routing.Resource("photos.comments", photoController()))
+
+	url := outcome.UrlByName(app, "photos.comments.update", outcome.Parameters{"photo": 12, "comment": 3})
+
+	require.Equal(t, "/photos/12/comments/3", url)
+}
+
+func resourceNames(routes inter.RouteCollection) []string {
+	var names []string
+	for _, route := range routes.All() {
+		if route.Method() == method.Get {
+			names = append(names, route.Name())
+		}
+	}
+
+	return names
+}
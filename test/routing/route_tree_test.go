@@ -0,0 +1,171 @@
+package routing
+
+import (
+	"fmt"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/http_helper"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_first_registered_route_wins(t *testing.T) {
+	routes := routing.Group(
+		routing.Get("/users/{id}", emptyController()).Name("dynamic"),
+		routing.Get("/users/create", emptyController()).Name("static"),
+	)
+
+	route := routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/create"}))
+
+	require.Equal(t, "dynamic", route.Name())
+}
+
+func Test_static_route_with_optional_slash(t *testing.T) {
+	routes := routing.Group(routing.Get("/users", emptyController()).Name("users"))
+
+	require.Equal(t, "users", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users"})).Name())
+	require.Equal(t, "users", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/"})).Name())
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users//"})).Name())
+}
+
+func Test_route_with_parameter_in_segment(t *testing.T) {
+	routes := routing.Group(
+		routing.Get("/users/edit-{id}", emptyController()).Name("edit"),
+	)
+	request := newRequest(http.Options{Method: method.Get, Url: "/users/edit-12"})
+
+	route := routes.Match(request)
+
+	require.Equal(t, "edit", route.Name())
+	require.Equal(t, 12, request.Parameter("id").Int())
+}
+
+func Test_static_route_with_prefixes(t *testing.T) {
+	routes := routing.Group(
+		routing.Group(
+			routing.Get("/roles", emptyController()).Name("roles"),
+		).Prefix("/users/"),
+	).Prefix("/admin")
+
+	route := routes.Match(newRequest(http.Options{Method: method.Get, Url: "/admin/users/roles"}))
+
+	require.Equal(t, "roles", route.Name())
+}
+
+func Test_match_routes_concurrently(t *testing.T) {
+	routes := routing.Group(
+		routing.Get("/users/{id}", emptyController()).Name("user"),
+		routing.Get("/posts", emptyController()).Name("posts"),
+	)
+
+	names := make(chan string, 20)
+	for i := 0; i < cap(names); i++ {
+		go func() {
+			names <- routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"})).Name()
+		}()
+	}
+
+	for i := 0; i < cap(names); i++ {
+		require.Equal(t, "user", <-names)
+	}
+}
+
+func Test_routes_compiled_again_after_change(t *testing.T) {
+	routes := routing.Group(routing.Get("/users", emptyController()).Name("users"))
+	routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users"}))
+
+	routes.Push(routing.NewRoute("/roles", method.Get, emptyController()).SetName("roles"))
+	route := routes.Match(newRequest(http.Options{Method: method.Get, Url: "/roles"}))
+
+	require.Equal(t, "roles", route.Name())
+}
+
+func Test_routes_added_after_match_are_decorated(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{id}", emptyController()).Name("users"))
+	routes.Pattern("id", routing.PatternNumber)
+	routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"}))
+
+	routes.Merge(routing.Get("/roles/{id}", emptyController()).Name("roles"))
+
+	require.Equal(t, "roles", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/roles/1/"})).Name())
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/roles/jip"})).Name())
+}
+
+func Test_constraint_changed_after_match(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{id}", emptyController()).Name("users")).Where("id", "[0-9]+")
+	require.Equal(t, "users", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"})).Name())
+
+	routes.Where("id", "[a-z]+")
+
+	require.Equal(t, "users", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/jip/"})).Name())
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"})).Name())
+}
+
+func Test_route_decorated_once(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{id}", emptyController()).Name("users")).Where("id", "[0-9]+")
+	routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"}))
+
+	routes.Prefix("/admin")
+	routes.Match(newRequest(http.Options{Method: method.Get, Url: "/admin/users/12"}))
+
+	require.Equal(t, `/users/{id:[0-9]+}{allow_slash:\/?}`, routes.All()[0].Uri())
+}
+
+func Benchmark_match_linear(b *testing.B) {
+	routes := benchmarkRoutes()
+	routing.DecorateRoutes(routes)
+	request := newRequest(http.Options{Method: method.Get, Url: "/resource499/12/edit"})
+	source := request.Source()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// The matching before the route tree: a mux route per route and per request
+		for _, route := range routes.All() {
+			var match mux.RouteMatch
+			if route.Method() == method.Get && http_helper.MuxFromRoute(route).Match(&source, &match) {
+				break
+			}
+		}
+	}
+}
+
+func Benchmark_match_tree(b *testing.B) {
+	routes := benchmarkRoutes()
+	request := newRequest(http.Options{Method: method.Get, Url: "/resource499/12/edit"})
+	routes.Match(request)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		routes.Match(request)
+	}
+}
+
+func Benchmark_match_tree_static(b *testing.B) {
+	routes := benchmarkRoutes()
+	request := newRequest(http.Options{Method: method.Get, Url: "/resource499"})
+	routes.Match(request)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		routes.Match(request)
+	}
+}
+
+// 500 resources with 4 routes each
+func benchmarkRoutes() *routing.RouteCollection {
+	var collections []inter.RouteCollection
+	for i := 0; i < 500; i++ {
+		uri := fmt.Sprintf("/resource%d", i)
+		collections = append(collections,
+			routing.Get(uri, emptyController()),
+			routing.Post(uri, emptyController()),
+			routing.Get(uri+"/{id}", emptyController()).Where("id", "[0-9]+"),
+			routing.Get(uri+"/{id}/edit", emptyController()),
+		)
+	}
+
+	return routing.Group(collections...)
+}
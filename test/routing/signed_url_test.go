@@ -0,0 +1,157 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/middleware"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/stretchr/testify/require"
+	net "net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_signed_url(t *testing.T) {
+	url := outcome.SignedUrlByName(signedApp(), "unsubscribe", outcome.Parameters{"user": 12}, outcome.Parameters{"list": "news"})
+
+	require.Regexp(t, `^https://example\.com/users/12/unsubscribe\?list=news&signature=[0-9a-f]{64}$`, url)
+	require.Equal(t, "unsubscribe", handleSigned(url).GetContent())
+}
+
+func Test_signed_url_changed(t *testing.T) {
+	url := outcome.SignedUrlByName(signedApp(), "unsubscribe", outcome.Parameters{"user": 12})
+
+	defer func() {
+		err := recover().(error)
+		require.True(t, errors.Is(err, middleware.InvalidSignatureError))
+		status, _ := errors.FindStatus(err)
+		require.Equal(t, net.StatusForbidden, status)
+	}()
+
+	handleSigned(strings.Replace(url, "/12/", "/13/", 1))
+}
+
+func Test_signed_url_with_other_key(t *testing.T) {
+	app := signedApp()
+	app.Bind("config.App.Key", "other key")
+	url := outcome.SignedUrlByName(app, "unsubscribe", outcome.Parameters{"user": 12})
+
+	require.PanicsWithError(t, "invalid signature", func() {
+		handleSigned(url)
+	})
+}
+
+func Test_signed_url_on_other_host(t *testing.T) {
+	url := outcome.SignedUrlByName(signedApp(), "unsubscribe", outcome.Parameters{"user": 12})
+
+	require.PanicsWithError(t, "invalid signature", func() {
+		handleSigned(strings.Replace(url, "https://example.com", "https://evil.com", 1))
+	})
+	require.PanicsWithError(t, "invalid signature", func() {
+		handleSigned(strings.Replace(url, "https://", "http://", 1))
+	})
+}
+
+func Test_signed_url_with_forged_forwarded_scheme(t *testing.T) {
+	url := outcome.SignedUrlByName(signedApp(), "unsubscribe", outcome.Parameters{"user": 12})
+
+	request := newRequest(http.Options{
+		Method: method.Get,
+		Url:    strings.Replace(url, "https://", "http://", 1),
+		Header: net.Header{"X-Forwarded-Proto": {"https"}},
+	})
+	request.App().Bind("config.App.Key", "secret key")
+
+	require.PanicsWithError(t, "invalid signature", func() {
+		middleware.NewPipeline(request.App()).
+			Send(request).
+			Through([]inter.HttpMiddleware{middleware.ValidateSignature{}}).
+			Then(signedRoutes().Match(request).Controller())
+	})
+}
+
+func Test_signed_url_of_tenant(t *testing.T) {
+	app := signedApp()
+	app.Singleton("routes", routing.Get("/invoices", emptyController()).Domain("{tenant}.example.com").Name("invoices"))
+	url := outcome.SignedUrlByName(app, "invoices", outcome.Parameters{"tenant": "jip"})
+	require.Regexp(t, `^https://jip\.example\.com/invoices\?signature=`, url)
+
+	validate := func(url string) inter.Response {
+		request := newRequest(http.Options{Method: method.Get, Url: url})
+		request.App().Bind("config.App.Key", "secret key")
+		return middleware.NewPipeline(request.App()).
+			Send(request).
+			Through([]inter.HttpMiddleware{middleware.ValidateSignature{}}).
+			Then(func(request inter.Request) inter.Response { return outcome.Html("invoices") })
+	}
+
+	require.Equal(t, "invoices", validate(url).GetContent())
+	require.PanicsWithError(t, "invalid signature", func() {
+		validate(strings.Replace(url, "jip.", "janneke.", 1))
+	})
+}
+
+func Test_temporary_signed_url(t *testing.T) {
+	url := outcome.TemporarySignedUrlByName(signedApp(), "unsubscribe", time.Now().Add(time.Hour), outcome.Parameters{"user": 12})
+
+	require.Contains(t, url, "expires=")
+	require.Equal(t, "unsubscribe", handleSigned(url).GetContent())
+}
+
+func Test_temporary_signed_url_expired(t *testing.T) {
+	url := outcome.TemporarySignedUrlByName(signedApp(), "unsubscribe", time.Now().Add(-time.Minute), outcome.Parameters{"user": 12})
+
+	require.PanicsWithError(t, "the URL has expired: invalid signature", func() {
+		handleSigned(url)
+	})
+}
+
+func Test_signed_url_without_app_key(t *testing.T) {
+	app := foundation.NewApp()
+	app.Singleton("routes", signedRoutes())
+
+	require.PanicsWithError(t, "no application key found in config.App.Key", func() {
+		outcome.SignedUrlByName(app, "unsubscribe", outcome.Parameters{"user": 12})
+	})
+}
+
+func Test_signed_url_with_invalid_app_key(t *testing.T) {
+	app := signedApp()
+	app.Bind("config.App.Key", 12345)
+
+	require.PanicsWithError(t, "no application key found in config.App.Key", func() {
+		outcome.SignedUrlByName(app, "unsubscribe", outcome.Parameters{"user": 12})
+	})
+}
+
+func signedRoutes() inter.RouteCollection {
+	return routing.Group(
+		routing.Get("/users/{user}/unsubscribe", func(request inter.Request) inter.Response {
+			return outcome.Html("unsubscribe")
+		}).Name("unsubscribe"),
+	)
+}
+
+func signedApp() inter.App {
+	app := foundation.NewApp()
+	app.Singleton("routes", signedRoutes())
+	app.Bind("config.App.Key", "secret key")
+	app.Bind("config.App.Url", "https://example.com")
+
+	return app
+}
+
+func handleSigned(url string) inter.Response {
+	request := newRequest(http.Options{Method: method.Get, Url: url})
+	request.App().Bind("config.App.Key", "secret key")
+
+	return middleware.NewPipeline(request.App()).
+		Send(request).
+		Through([]inter.HttpMiddleware{middleware.ValidateSignature{}}).
+		Then(signedRoutes().Match(request).Controller())
+}
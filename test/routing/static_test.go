@@ -0,0 +1,152 @@
+package routing
+
+import (
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	routing2 "github.com/confetti-framework/foundation/http/routing"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	net "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_static_file(t *testing.T) {
+	recorder := serveStatic(t, "/assets/css/app.css", nil, routing2.StaticOptions{})
+
+	require.Equal(t, net.StatusOK, recorder.Code)
+	require.Equal(t, "body {}", recorder.Body.String())
+	require.Contains(t, recorder.Header().Get("Content-Type"), "text/css")
+	require.Empty(t, recorder.Header().Get("Content-Disposition"))
+	require.Empty(t, recorder.Header().Get("Cache-Control"))
+}
+
+func Test_static_file_with_cache_control(t *testing.T) {
+	options := routing2.StaticOptions{CacheControl: "public, max-age=31536000"}
+
+	recorder := serveStatic(t, "/assets/css/app.css", nil, options)
+
+	require.Equal(t, "public, max-age=31536000", recorder.Header().Get("Cache-Control"))
+}
+
+func Test_static_index_file(t *testing.T) {
+	recorder := serveStatic(t, "/assets/", nil, routing2.StaticOptions{})
+
+	require.Equal(t, "<h1>Home</h1>", recorder.Body.String())
+}
+
+func Test_static_index_file_disabled(t *testing.T) {
+	require.Panics(t, func() {
+		serveStatic(t, "/assets/", nil, routing2.StaticOptions{Index: "-"})
+	})
+}
+
+func Test_static_precompressed_brotli(t *testing.T) {
+	recorder := serveStatic(t, "/assets/js/app.js", net.Header{"Accept-Encoding": {"gzip, br"}}, routing2.StaticOptions{})
+
+	require.Equal(t, "brotli", recorder.Body.String())
+	require.Equal(t, "br", recorder.Header().Get("Content-Encoding"))
+	require.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+	require.Contains(t, recorder.Header().Get("Content-Type"), "javascript")
+}
+
+func Test_static_precompressed_gzip(t *testing.T) {
+	recorder := serveStatic(t, "/assets/js/app.js", net.Header{"Accept-Encoding": {"gzip, br;q=0"}}, routing2.StaticOptions{})
+
+	require.Equal(t, "gzip", recorder.Body.String())
+	require.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+}
+
+func Test_static_without_accepted_encoding(t *testing.T) {
+	recorder := serveStatic(t, "/assets/js/app.js", nil, routing2.StaticOptions{})
+
+	require.Equal(t, "plain", recorder.Body.String())
+	require.Empty(t, recorder.Header().Get("Content-Encoding"))
+	require.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+}
+
+func Test_static_without_precompressed_file(t *testing.T) {
+	recorder := serveStatic(t, "/assets/css/app.css", net.Header{"Accept-Encoding": {"gzip, br"}}, routing2.StaticOptions{})
+
+	require.Equal(t, "body {}", recorder.Body.String())
+	require.Empty(t, recorder.Header().Get("Vary"))
+}
+
+func Test_static_encoding_with_zero_quality(t *testing.T) {
+	for _, acceptEncoding := range []string{"br;q=0, gzip;q=0.0", "br; q=0.000, *;q=0", "gzip;q=invalid"} {
+		recorder := serveStatic(t, "/assets/js/app.js", net.Header{"Accept-Encoding": {acceptEncoding}}, routing2.StaticOptions{})
+
+		require.Equal(t, "plain", recorder.Body.String(), acceptEncoding)
+		require.Empty(t, recorder.Header().Get("Content-Encoding"), acceptEncoding)
+	}
+}
+
+func Test_static_encoding_by_wildcard(t *testing.T) {
+	recorder := serveStatic(t, "/assets/js/app.js", net.Header{"Accept-Encoding": {"br;q=0, *"}}, routing2.StaticOptions{})
+
+	require.Equal(t, "gzip", recorder.Body.String())
+	require.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+}
+
+func Test_static_file_not_found(t *testing.T) {
+	require.PanicsWithError(t, "no static file found for /assets/missing.css: no match was found for the specified URL", func() {
+		serveStatic(t, "/assets/missing.css", nil, routing2.StaticOptions{})
+	})
+}
+
+func Test_static_path_traversal(t *testing.T) {
+	defer func() {
+		err, ok := recover().(error)
+		require.True(t, ok)
+		require.True(t, errors.Is(err, routing2.RouteNotFoundError))
+	}()
+
+	serveStatic(t, "/assets/%2e%2e/secret.txt", nil, routing2.StaticOptions{})
+}
+
+func Test_static_with_middleware(t *testing.T) {
+	dir := staticDir(t)
+	routes := routing2.Static("/assets", dir).Middleware(MockedMiddleware1{})
+
+	for _, route := range routes.All() {
+		require.Len(t, route.(*routing2.Route).Middleware(), 1)
+	}
+}
+
+func serveStatic(t *testing.T, url string, header net.Header, options routing2.StaticOptions) *httptest.ResponseRecorder {
+	routes := routing2.Static("/assets", staticDir(t), options)
+	request := newRequest(http.Options{Method: method.Get, Url: url, Header: header})
+	response := routes.Match(request).Controller()(request)
+
+	recorder := httptest.NewRecorder()
+	source := request.Source()
+	response.(net.Handler).ServeHTTP(recorder, &source)
+
+	return recorder
+}
+
+// Create a directory with assets next to a file that should not be served
+func staticDir(t *testing.T) string {
+	root, err := ioutil.TempDir("", "static")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(root) })
+
+	files := map[string]string{
+		"secret.txt":          "secret",
+		"public/index.html":   "<h1>Home</h1>",
+		"public/css/app.css":  "body {}",
+		"public/js/app.js":    "plain",
+		"public/js/app.js.gz": "gzip",
+		"public/js/app.js.br": "brotli",
+	}
+	for name, content := range files {
+		filename := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		require.NoError(t, ioutil.WriteFile(filename, []byte(content), 0644))
+	}
+
+	return filepath.Join(root, "public")
+}
@@ -0,0 +1,114 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/middleware"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/require"
+	net "net/http"
+	"testing"
+	"time"
+)
+
+func Test_timeout_not_exceeded(t *testing.T) {
+	response := handleWithTimeout(time.Second, func(request inter.Request) inter.Response {
+		return outcome.Html("users")
+	})
+
+	require.Equal(t, "users", response.GetContent())
+}
+
+func Test_timeout_exceeded(t *testing.T) {
+	canceled := make(chan bool, 1)
+
+	defer func() {
+		err := recover().(error)
+		require.True(t, errors.Is(err, middleware.RequestTimeoutError))
+		require.Equal(t, "no response within 10ms for /users: request timeout", err.Error())
+		status, _ := errors.FindStatus(err)
+		require.Equal(t, net.StatusServiceUnavailable, status)
+		// The controller can stop its work
+		require.True(t, <-canceled)
+	}()
+
+	handleWithTimeout(10*time.Millisecond, func(request inter.Request) inter.Response {
+		<-http.Context(request).Done()
+		canceled <- true
+		return outcome.Html("too late")
+	})
+}
+
+func Test_timeout_with_panic_in_controller(t *testing.T) {
+	require.PanicsWithValue(t, "controller failed", func() {
+		handleWithTimeout(time.Second, func(request inter.Request) inter.Response {
+			panic("controller failed")
+		})
+	})
+}
+
+func Test_timeout_with_log_level(t *testing.T) {
+	for _, expected := range []log_level.Level{log_level.ERROR, log_level.EMERGENCY} {
+		level := expected
+		err := timeoutError(middleware.Timeout{Duration: time.Millisecond, Level: &level})
+
+		actual, _ := errors.FindLevel(err)
+		require.Equal(t, expected, actual)
+		require.True(t, errors.Is(err, middleware.RequestTimeoutError))
+	}
+}
+
+func Test_timeout_with_default_log_level(t *testing.T) {
+	err := timeoutError(middleware.Timeout{Duration: time.Millisecond})
+
+	level, _ := errors.FindLevel(err)
+	require.Equal(t, middleware.DefaultTimeoutLevel, level)
+}
+
+func timeoutError(timeout middleware.Timeout) (err error) {
+	request := newRequest(http.Options{Method: method.Get, Url: "/users"})
+
+	defer func() {
+		err = recover().(error)
+	}()
+
+	middleware.NewPipeline(request.App()).
+		Send(request).
+		Through([]inter.HttpMiddleware{timeout}).
+		Then(func(request inter.Request) inter.Response {
+			<-http.Context(request).Done()
+			return outcome.Html("too late")
+		})
+
+	return nil
+}
+
+func Test_timeout_does_not_change_request(t *testing.T) {
+	request := newRequest(http.Options{Method: method.Get, Url: "/users"})
+	var controllerRequest inter.Request
+
+	middleware.NewPipeline(request.App()).
+		Send(request).
+		Through([]inter.HttpMiddleware{middleware.Timeout{Duration: time.Second}}).
+		Then(func(request inter.Request) inter.Response {
+			controllerRequest = request
+			return outcome.Html("users")
+		})
+
+	_, hasDeadline := http.Context(request).Deadline()
+	require.False(t, hasDeadline)
+	_, hasDeadline = http.Context(controllerRequest).Deadline()
+	require.True(t, hasDeadline)
+}
+
+func handleWithTimeout(duration time.Duration, controller inter.Controller) inter.Response {
+	request := newRequest(http.Options{Method: method.Get, Url: "/users"})
+
+	return middleware.NewPipeline(request.App()).
+		Send(request).
+		Through([]inter.HttpMiddleware{middleware.Timeout{Duration: duration}}).
+		Then(controller)
+}
@@ -0,0 +1,182 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/stretchr/testify/require"
+	net "net/http"
+	"testing"
+)
+
+func Test_url_by_name_is_relative(t *testing.T) {
+	url, err := outcome.UrlByNameE(urlApp(), "user", outcome.Parameters{"user": 12}, outcome.Parameters{"tab": "posts"})
+
+	require.NoError(t, err)
+	require.Equal(t, "/users/12?tab=posts", url)
+}
+
+func Test_url_by_name_with_error(t *testing.T) {
+	_, err := outcome.UrlByNameE(urlApp(), "unknown")
+
+	require.EqualError(t, err, "no route found matching name unknown")
+}
+
+func Test_url_by_name_with_missing_parameter(t *testing.T) {
+	_, err := outcome.UrlByNameE(urlApp(), "user")
+
+	require.Error(t, err)
+}
+
+func Test_absolute_url_from_request(t *testing.T) {
+	app := urlApp()
+	app.Bind("config.Http.TrustedProxies", []string{"192.0.2.0/24"})
+	app.Bind("request", http.NewRequest(http.Options{
+		Method: method.Get,
+		Host:   "example.com:8080",
+		Header: net.Header{"X-Forwarded-Proto": {"https"}},
+	}))
+
+	url := outcome.NewUrlGenerator(app).AbsoluteRoute("user", outcome.Parameters{"user": 12})
+
+	require.Equal(t, "https://example.com:8080/users/12", url)
+}
+
+func Test_absolute_url_ignores_scheme_of_untrusted_proxy(t *testing.T) {
+	app := urlApp()
+	app.Bind("config.Http.TrustedProxies", "10.0.0.1, 10.0.0.2")
+	app.Bind("request", http.NewRequest(http.Options{
+		Method: method.Get,
+		Host:   "example.com",
+		Header: net.Header{"X-Forwarded-Proto": {"https"}},
+	}))
+
+	url := outcome.NewUrlGenerator(app).AbsoluteRoute("user", outcome.Parameters{"user": 12})
+
+	require.Equal(t, "http://example.com/users/12", url)
+}
+
+func Test_absolute_url_from_base_url(t *testing.T) {
+	app := urlApp()
+	app.Bind("config.App.Url", "http://localhost:8000/")
+
+	url := outcome.NewUrlGenerator(app).AbsoluteRoute("user", outcome.Parameters{"user": 12})
+
+	require.Equal(t, "http://localhost:8000/users/12", url)
+}
+
+func Test_absolute_url_without_base_url(t *testing.T) {
+	_, err := outcome.NewUrlGenerator(urlApp()).AbsoluteRouteE("user", outcome.Parameters{"user": 12})
+
+	require.True(t, errors.Is(err, outcome.BaseUrlNotFoundError))
+}
+
+func Test_absolute_url_with_invalid_base_url(t *testing.T) {
+	app := urlApp()
+	app.Bind("config.App.Url", "localhost")
+
+	_, err := outcome.NewUrlGenerator(app).AbsoluteRouteE("user", outcome.Parameters{"user": 12})
+
+	require.EqualError(t, err, "invalid base URL localhost: no base URL found in config.App.Url")
+}
+
+func Test_domain_url_uses_scheme_of_base_url(t *testing.T) {
+	app := urlApp()
+	app.Bind("config.App.Url", "http://endless.horse")
+
+	url := outcome.UrlByName(app, "dashboard", outcome.Parameters{"account": "big"})
+
+	require.Equal(t, "http://big.endless.horse/dashboard", url)
+}
+
+func Test_domain_url_with_parameters_of_current_host(t *testing.T) {
+	app := urlApp()
+	app.Bind("request", http.NewRequest(http.Options{Method: method.Get, Host: "big.endless.horse"}))
+
+	generator := outcome.NewUrlGenerator(app)
+
+	require.Equal(t, "http://big.endless.horse/dashboard", generator.Route("dashboard"))
+	require.Equal(t, "http://small.endless.horse/dashboard", generator.Route("dashboard", outcome.Parameters{"account": "small"}))
+}
+
+func Test_domain_url_with_missing_parameter(t *testing.T) {
+	_, err := outcome.UrlByNameE(urlApp(), "dashboard")
+
+	require.Error(t, err)
+}
+
+func Test_url_to_path(t *testing.T) {
+	app := urlApp()
+	app.Bind("config.App.Url", "https://endless.horse")
+	generator := outcome.NewUrlGenerator(app)
+
+	require.Equal(t, "https://endless.horse/docs?page=2", generator.To("docs", outcome.Parameters{"page": 2}))
+	require.Equal(t, "https://other.horse/docs", generator.To("https://other.horse/docs"))
+	require.Equal(t, "https://endless.horse", generator.Root())
+}
+
+func Test_url_generator_bound_for_request(t *testing.T) {
+	request := newRequest(http.Options{Method: method.Get, Url: "/users/12", Host: "endless.horse"})
+	app := request.App()
+	app.Singleton("routes", urlRoutes())
+
+	response := http.Kernel{App: &app}.Handle(request)
+
+	require.Equal(t, "http://endless.horse/users/12", response.GetContent())
+}
+
+func Test_url_generation_does_not_change_route(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{id}", emptyController()).Name("user"))
+	app := foundation.NewApp()
+	app.Singleton("routes", routes)
+	routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"}))
+	uri := routes.All()[0].Uri()
+
+	require.Equal(t, "/users/12", outcome.UrlByName(app, "user", outcome.Parameters{"id": 12}))
+	require.Equal(t, uri, routes.All()[0].Uri())
+
+	// A constraint set after generating a URL is still applied
+	routes.Where("id", "[0-9]+")
+	require.Equal(t, "", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/jip"})).Name())
+	require.Equal(t, "user", routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12/"})).Name())
+}
+
+func Test_generate_urls_while_matching(t *testing.T) {
+	routes := routing.Group(routing.Get("/users/{id}", emptyController()).Name("user"))
+	app := foundation.NewApp()
+	app.Singleton("routes", routes)
+	routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12"}))
+
+	names := make(chan string, 20)
+	for i := 0; i < cap(names); i++ {
+		go func() {
+			names <- routes.Match(newRequest(http.Options{Method: method.Get, Url: "/users/12/"})).Name()
+		}()
+		require.Equal(t, "/users/12", outcome.UrlByName(app, "user", outcome.Parameters{"id": 12}))
+	}
+
+	for i := 0; i < cap(names); i++ {
+		require.Equal(t, "user", <-names)
+	}
+}
+
+func urlRoutes() inter.RouteCollection {
+	return routing.Group(
+		routing.Get("/users/{user}", func(request inter.Request) inter.Response {
+			generator := request.Make("url").(*outcome.UrlGenerator)
+			return outcome.Html(generator.AbsoluteRoute("user", outcome.Parameters{"user": request.Parameter("user").Raw()}))
+		}).Name("user"),
+		routing.Get("/dashboard", emptyController()).Domain("{account}.endless.horse").Name("dashboard"),
+	)
+}
+
+func urlApp() inter.App {
+	app := foundation.NewApp()
+	app.Singleton("routes", urlRoutes())
+
+	return app
+}
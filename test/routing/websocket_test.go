@@ -0,0 +1,132 @@
+package routing
+
+import (
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/errors"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/method"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/confetti-framework/foundation/test/mock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	net "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type rejectWithoutToken struct{}
+
+func (r rejectWithoutToken) Handle(request inter.Request, next inter.Next) inter.Response {
+	if request.Parameter("token").String() != "secret" {
+		return outcome.Html("Unauthorized").Status(net.StatusUnauthorized)
+	}
+	return next(request)
+}
+
+func Test_websocket_echo_with_request(t *testing.T) {
+	routes := routing.WebSocket("/chat/{room}", func(connection *outcome.WebSocketConnection, request inter.Request) error {
+		message, err := connection.ReadText()
+		if err != nil {
+			return err
+		}
+		return connection.WriteText(request.Parameter("room").String() + ": " + message)
+	})
+
+	conn, _, err := dialWebSocket(t, routes, "/chat/general")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, "general: hello", string(message))
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+}
+
+func Test_websocket_json(t *testing.T) {
+	routes := routing.WebSocket("/ws", func(connection *outcome.WebSocketConnection, request inter.Request) error {
+		var message map[string]int
+		if err := connection.ReadJson(&message); err != nil {
+			return err
+		}
+		return connection.WriteJson(map[string]int{"count": message["count"] + 1})
+	})
+
+	conn, _, err := dialWebSocket(t, routes, "/ws")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(map[string]int{"count": 1}))
+	var result map[string]int
+	require.NoError(t, conn.ReadJSON(&result))
+	require.Equal(t, map[string]int{"count": 2}, result)
+}
+
+func Test_websocket_handler_error_closes_connection(t *testing.T) {
+	routes := routing.WebSocket("/ws", func(connection *outcome.WebSocketConnection, request inter.Request) error {
+		return errors.New("database is down")
+	})
+
+	conn, _, err := dialWebSocket(t, routes, "/ws")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseInternalServerErr))
+	require.NotContains(t, err.Error(), "database")
+}
+
+func Test_websocket_middleware_runs_before_upgrade(t *testing.T) {
+	var called bool
+	routes := routing.WebSocket("/ws/{token}", func(connection *outcome.WebSocketConnection, request inter.Request) error {
+		called = true
+		return nil
+	}).Middleware(rejectWithoutToken{})
+
+	_, response, err := dialWebSocket(t, routes, "/ws/wrong")
+
+	require.Equal(t, websocket.ErrBadHandshake, err)
+	require.Equal(t, net.StatusUnauthorized, response.StatusCode)
+	require.False(t, called)
+
+	conn, _, err := dialWebSocket(t, routes, "/ws/secret")
+	require.NoError(t, err)
+	_ = conn.Close()
+}
+
+func Test_websocket_only_get_route(t *testing.T) {
+	routes := routing.WebSocket("/ws", nil)
+
+	require.Len(t, routes.All(), 1)
+	require.Equal(t, method.Get, routes.All()[0].Method())
+}
+
+func Test_websocket_url_by_name(t *testing.T) {
+	app := foundation.NewApp()
+	app.Singleton("routes", routing.Group(
+		routing.WebSocket("/chat/{room}", nil).Name("chat"),
+	))
+
+	url := outcome.UrlByName(app, "chat", outcome.Parameters{"room": "general"})
+
+	require.Equal(t, "/chat/general", url)
+}
+
+func dialWebSocket(t *testing.T, routes inter.RouteCollection, uri string) (*websocket.Conn, *net.Response, error) {
+	server := httptest.NewServer(net.HandlerFunc(func(writer net.ResponseWriter, request *net.Request) {
+		var app inter.App = foundation.NewApp()
+		app.Bind("outcome_html_encoders", mock.HtmlEncoders)
+		app.Bind("response_decorators", []inter.ResponseDecorator{})
+		app.Singleton("routes", routes)
+		app.Bind((*inter.HttpKernel)(nil), http.Kernel{App: &app})
+		http.HandleHttpKernel(app, writer, request)
+	}))
+	t.Cleanup(server.Close)
+
+	return websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+uri, nil)
+}
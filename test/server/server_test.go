@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"github.com/confetti-framework/contract/inter"
+	"github.com/confetti-framework/foundation"
+	"github.com/confetti-framework/foundation/console"
+	"github.com/confetti-framework/foundation/http"
+	"github.com/confetti-framework/foundation/http/outcome"
+	"github.com/confetti-framework/foundation/http/routing"
+	"github.com/confetti-framework/foundation/test/mock"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	network "net"
+	net "net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func Test_server_config_defaults(t *testing.T) {
+	server, err := http.NewServer(foundation.NewApp())
+
+	require.NoError(t, err)
+	require.Equal(t, ":8080", server.Config.Addr)
+	require.Equal(t, 10*time.Second, server.Config.ShutdownTimeout)
+	require.Equal(t, time.Duration(0), server.Config.WriteTimeout)
+}
+
+func Test_server_config_from_app(t *testing.T) {
+	app := foundation.NewApp()
+	app.Bind("config.Http", map[string]interface{}{
+		"Addr":        "127.0.0.1:9000",
+		"IdleTimeout": "5s",
+		"TlsCert":     "cert.pem",
+	})
+
+	server, err := http.NewServer(app)
+
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:9000", server.Config.Addr)
+	require.Equal(t, 5*time.Second, server.Config.IdleTimeout)
+	require.Equal(t, "cert.pem", server.Config.TlsCert)
+	require.Equal(t, 10*time.Second, server.Config.ReadHeaderTimeout)
+}
+
+func Test_server_shuts_down_gracefully(t *testing.T) {
+	started := make(chan bool)
+	release := make(chan bool)
+	var terminated bool
+
+	var app inter.App = foundation.NewApp()
+	app.Bind("outcome_html_encoders", mock.HtmlEncoders)
+	app.Bind("response_decorators", []inter.ResponseDecorator{})
+	app.Bind((*inter.HttpKernel)(nil), http.Kernel{App: &app})
+	app.Singleton("routes", routing.Group(
+		routing.Get("/slow", func(request inter.Request) inter.Response {
+			started <- true
+			<-release
+			return outcome.Html("finished")
+		}),
+	))
+	app.(*foundation.Application).OnTerminating(func(ctx context.Context, app inter.App) error {
+		terminated = true
+		return nil
+	})
+
+	listener, err := network.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := http.Server{App: app, Signals: []os.Signal{syscall.SIGUSR1}}
+	server.Config.ShutdownTimeout = 5 * time.Second
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	bodies := make(chan string, 1)
+	errs := make(chan error, 1)
+	go func() {
+		response, err := net.Get("http://" + listener.Addr().String() + "/slow")
+		errs <- err
+		if err != nil {
+			bodies <- ""
+			return
+		}
+		body, _ := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		bodies <- string(body)
+	}()
+
+	// Stop the server while the request is handled
+	<-started
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+	time.Sleep(50 * time.Millisecond)
+	release <- true
+
+	require.NoError(t, <-errs)
+	require.Equal(t, "finished", <-bodies)
+	require.NoError(t, <-served)
+	require.True(t, terminated)
+
+	// New connections are refused
+	_, err = net.Get("http://" + listener.Addr().String() + "/slow")
+	require.Error(t, err)
+}
+
+func Test_serve_command_stops_gracefully_on_sigterm(t *testing.T) {
+	started := make(chan bool)
+	release := make(chan bool)
+
+	var app inter.App = foundation.NewApp()
+	app.Bind("outcome_html_encoders", mock.HtmlEncoders)
+	app.Bind("response_decorators", []inter.ResponseDecorator{})
+	app.Bind((*inter.HttpKernel)(nil), http.Kernel{App: &app})
+	app.Singleton("routes", routing.Group(
+		routing.Get("/slow", func(request inter.Request) inter.Response {
+			started <- true
+			<-release
+			return outcome.Html("finished")
+		}),
+	))
+	addr := freeAddr(t)
+	app.Bind("config.App.OsArgs", []interface{}{"/main", "serve", "--addr", addr})
+
+	var output bytes.Buffer
+	codes := make(chan inter.ExitCode, 1)
+	go func() { codes <- console.Kernel{App: app, Writer: &output, WriterErr: &output}.Handle() }()
+
+	bodies := make(chan string, 1)
+	errs := make(chan error, 1)
+	go func() {
+		body, err := getWithRetry("http://" + addr + "/slow")
+		errs <- err
+		bodies <- body
+	}()
+
+	// Stop the server while the request is handled
+	<-started
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+	time.Sleep(50 * time.Millisecond)
+	release <- true
+
+	require.NoError(t, <-errs)
+	require.Equal(t, "finished", <-bodies)
+	require.Equal(t, inter.Success, <-codes)
+	require.Contains(t, output.String(), "Server stopped")
+}
+
+func Test_request_refused_after_terminate(t *testing.T) {
+	app := foundation.NewApp()
+	require.NoError(t, app.Terminate(context.Background()))
+	recorder := httptest.NewRecorder()
+
+	http.HandleHttpKernel(app, recorder, httptest.NewRequest("GET", "/", nil))
+
+	require.Equal(t, net.StatusServiceUnavailable, recorder.Code)
+	require.Equal(t, "close", recorder.Header().Get("Connection"))
+}
+
+func freeAddr(t *testing.T) string {
+	listener, err := network.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+// The server may not be listening yet
+func getWithRetry(url string) (string, error) {
+	var err error
+	for i := 0; i < 50; i++ {
+		var response *net.Response
+		response, err = net.Get(url)
+		if err != nil {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		body, err := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		return string(body), err
+	}
+
+	return "", err
+}